@@ -0,0 +1,208 @@
+package rangetype
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// The largest column and row numbers in a spreadsheet, as in Excel, for whole columns and whole rows
+	MaxColumns = 16384   // XFD
+	MaxRows    = 1048576 // 2^20
+)
+
+// CellRange is a rectangular range of cells in a spreadsheet, like "A1:C10",
+// with one integer range for the column numbers and one for the row numbers, which both start at 1.
+// Whole columns, like "B:B", include all rows, up to MaxRows, and whole rows, like "3:5",
+// include all columns, up to MaxColumns.
+type CellRange struct {
+	Columns  *Range
+	Rows     *Range
+	absolute [4]bool // "$" markers, for the first column, first row, last column and last row
+}
+
+// cellReference is one side of a cell range, like "$A$1", "B" or "3"
+type cellReference struct {
+	column, row                 int // 0 if missing
+	absoluteColumn, absoluteRow bool
+}
+
+// ColumnName returns the letters for a column number, like "A" for 1 and "AA" for 27
+func ColumnName(n int) string {
+	var letters []byte
+	for n > 0 {
+		n--
+		letters = append([]byte{byte('A' + n%26)}, letters...)
+		n /= 26
+	}
+	return string(letters)
+}
+
+// ColumnNumber returns the column number for the given letters, like 1 for "A" and 27 for "AA"
+func ColumnNumber(letters string) (int, error) {
+	if letters == "" {
+		return 0, errors.New("MISSING COLUMN")
+	}
+	n := 0
+	for _, c := range strings.ToUpper(letters) {
+		if c < 'A' || c > 'Z' {
+			return 0, errors.New("INVALID COLUMN: " + letters)
+		}
+		n = n*26 + int(c-'A'+1)
+		if n > MaxColumns {
+			return 0, errors.New("COLUMN OUT OF RANGE: " + letters)
+		}
+	}
+	return n, nil
+}
+
+// parseCellReference parses one side of a cell range, like "$A$1", "B" or "3"
+func parseCellReference(s string) (cellReference, error) {
+	var ref cellReference
+	if strings.HasPrefix(s, "$") {
+		ref.absoluteColumn = true
+		s = s[1:]
+	}
+	i := strings.IndexFunc(s, func(c rune) bool { return !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z') })
+	if i < 0 {
+		i = len(s)
+	}
+	letters, digits := s[:i], s[i:]
+	if letters == "" && ref.absoluteColumn && digits != "" {
+		// A row like "$3", where the "$" belongs to the row
+		ref.absoluteColumn, ref.absoluteRow = false, true
+	} else if strings.HasPrefix(digits, "$") && letters != "" {
+		ref.absoluteRow = true
+		digits = digits[1:]
+	}
+	var err error
+	if letters != "" {
+		if ref.column, err = ColumnNumber(letters); err != nil {
+			return ref, err
+		}
+	}
+	if digits != "" {
+		row, err := strconv.ParseUint(digits, 10, 32)
+		if err != nil || row < 1 || row > MaxRows {
+			return ref, errors.New("INVALID ROW: " + digits)
+		}
+		ref.row = int(row)
+	}
+	if ref.column == 0 && ref.row == 0 {
+		return ref, errors.New("INVALID CELL REFERENCE")
+	}
+	return ref, nil
+}
+
+// ParseA1 parses a cell range in A1 notation, like "A1:C10", "B:B", "3:5", "$A$1:$B$2" or "C3".
+// The cells may be given in any order, so "C10:A1" is the same as "A1:C10".
+func ParseA1(s string) (*CellRange, error) {
+	s = strings.TrimSpace(s)
+	left, right, found := strings.Cut(s, ":")
+	if !found {
+		right = left
+	}
+	first, err := parseCellReference(left)
+	if err != nil {
+		return nil, errors.New("INVALID CELL RANGE: " + s + ", " + err.Error())
+	}
+	last, err := parseCellReference(right)
+	if err != nil {
+		return nil, errors.New("INVALID CELL RANGE: " + s + ", " + err.Error())
+	}
+	if (first.column == 0) != (last.column == 0) || (first.row == 0) != (last.row == 0) || (!found && first.row == 0) || (!found && first.column == 0) {
+		return nil, errors.New("INVALID CELL RANGE: " + s)
+	}
+	// Order the columns and the rows, together with their "$" markers
+	if first.column > last.column {
+		first.column, last.column = last.column, first.column
+		first.absoluteColumn, last.absoluteColumn = last.absoluteColumn, first.absoluteColumn
+	}
+	if first.row > last.row {
+		first.row, last.row = last.row, first.row
+		first.absoluteRow, last.absoluteRow = last.absoluteRow, first.absoluteRow
+	}
+	c := &CellRange{absolute: [4]bool{first.absoluteColumn, first.absoluteRow, last.absoluteColumn, last.absoluteRow}}
+	c.Columns = cellAxis(first.column, last.column, MaxColumns)
+	c.Rows = cellAxis(first.row, last.row, MaxRows)
+	return c, nil
+}
+
+// cellAxis returns an inclusive integer range from a to b, or from 1 to max if a and b are 0
+func cellAxis(a, b, max int) *Range {
+	if a == 0 {
+		a, b = 1, max
+	}
+	if a > b {
+		a, b = b, a
+	}
+	return &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: float64(a), to: float64(b), step: 1}
+}
+
+// wholeColumns checks if the cell range includes all rows, like "B:B"
+func (c *CellRange) wholeColumns() bool {
+	return c.Rows.from == 1 && c.Rows.to == MaxRows
+}
+
+// wholeRows checks if the cell range includes all columns, like "3:5"
+func (c *CellRange) wholeRows() bool {
+	return c.Columns.from == 1 && c.Columns.to == MaxColumns
+}
+
+// String returns the cell range in A1 notation, like "A1:C10", "B:B", "3:5" or "C3"
+func (c *CellRange) String() string {
+	dollar := func(absolute bool) string {
+		if absolute {
+			return "$"
+		}
+		return ""
+	}
+	column := func(n float64, absolute bool) string { return dollar(absolute) + ColumnName(int(n)) }
+	row := func(n float64, absolute bool) string { return dollar(absolute) + strconv.Itoa(int(n)) }
+	switch {
+	case c.wholeColumns() && !c.wholeRows():
+		return column(c.Columns.from, c.absolute[0]) + ":" + column(c.Columns.to, c.absolute[2])
+	case c.wholeRows() && !c.wholeColumns():
+		return row(c.Rows.from, c.absolute[1]) + ":" + row(c.Rows.to, c.absolute[3])
+	}
+	first := column(c.Columns.from, c.absolute[0]) + row(c.Rows.from, c.absolute[1])
+	last := column(c.Columns.to, c.absolute[2]) + row(c.Rows.to, c.absolute[3])
+	if first == last {
+		return first
+	}
+	return first + ":" + last
+}
+
+// Has checks if the given cell is in the cell range
+func (c *CellRange) Has(column, row int) bool {
+	return c.Columns.Valid(float64(column)) && c.Rows.Valid(float64(row))
+}
+
+// Len returns the number of cells in the cell range
+func (c *CellRange) Len() int {
+	return int(c.Columns.to-c.Columns.from+1) * int(c.Rows.to-c.Rows.from+1)
+}
+
+// ForEach calls the given function for each cell in the cell range, in row-major order,
+// so that all the cells in the first row are visited before the cells in the second row.
+func (c *CellRange) ForEach(f func(column, row int)) {
+	c.Rows.ForEach(func(row float64) {
+		c.Columns.ForEach(func(column float64) {
+			f(int(column), int(row))
+		})
+	})
+}
+
+// ForEachWithBreak calls the given function for each cell in the cell range, in row-major order.
+// If the given function returns true, the remaining cells are skipped.
+func (c *CellRange) ForEachWithBreak(f func(column, row int) bool) {
+	c.Rows.ForEachWithBreak(func(row float64) bool {
+		stop := false
+		c.Columns.ForEachWithBreak(func(column float64) bool {
+			stop = f(int(column), int(row))
+			return stop
+		})
+		return stop
+	})
+}
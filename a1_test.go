@@ -0,0 +1,94 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestColumnNames(t *testing.T) {
+	for n, name := range map[int]string{1: "A", 2: "B", 26: "Z", 27: "AA", 28: "AB", 52: "AZ", 53: "BA", 702: "ZZ", 703: "AAA", MaxColumns: "XFD"} {
+		assert.Equal(t, ColumnName(n), name)
+		number, err := ColumnNumber(name)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, number, n)
+	}
+	number, err := ColumnNumber("ab")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, number, 28)
+	for _, name := range []string{"", "A1", "XFE", "ÆØÅ"} {
+		_, err := ColumnNumber(name)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestParseA1(t *testing.T) {
+	for s, expected := range map[string][4]float64{
+		"A1:C10":      {1, 3, 1, 10},
+		"C10:A1":      {1, 3, 1, 10},
+		"B:B":         {2, 2, 1, MaxRows},
+		"B:D":         {2, 4, 1, MaxRows},
+		"3:5":         {1, MaxColumns, 3, 5},
+		"$A$1:$B$2":   {1, 2, 1, 2},
+		"C3":          {3, 3, 3, 3},
+		"$C3":         {3, 3, 3, 3},
+		"AA100:AB101": {27, 28, 100, 101},
+		"$3:$5":       {1, MaxColumns, 3, 5},
+	} {
+		c, err := ParseA1(s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, [4]float64{c.Columns.First(), c.Columns.Last(), c.Rows.First(), c.Rows.Last()}, expected)
+	}
+
+	for _, s := range []string{"", "A", "1", "A1:B", "A:1", "1:A1", "A0", "A1:C1048577", "XFE1", "A1:B2:C3", "A$", "1A"} {
+		_, err := ParseA1(s)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestFormatA1(t *testing.T) {
+	for s, expected := range map[string]string{
+		"A1:C10":    "A1:C10",
+		"c10:a1":    "A1:C10",
+		"B:B":       "B:B",
+		"3:5":       "3:5",
+		"$A$1:$B$2": "$A$1:$B$2",
+		"A$1:$B2":   "A$1:$B2",
+		"$B:D":      "$B:D",
+		"$3:5":      "$3:5",
+		"C10:$A1":   "$A1:C10",
+		"A$10:C1":   "A1:C$10",
+		"D:$B":      "$B:D",
+		"5:$3":      "$3:5",
+		"C3":        "C3",
+		"C3:C3":     "C3",
+		"A:XFD":     "A1:XFD1048576",
+	} {
+		c, err := ParseA1(s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, c.String(), expected)
+	}
+}
+
+func TestCellRangeForEach(t *testing.T) {
+	c, err := ParseA1("A1:B3")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, c.Len(), 6)
+	var cells []string
+	c.ForEach(func(column, row int) {
+		cells = append(cells, ColumnName(column)+string(rune('0'+row)))
+	})
+	assert.Equal(t, cells, []string{"A1", "B1", "A2", "B2", "A3", "B3"})
+	assert.Equal(t, c.Has(2, 3), true)
+	assert.Equal(t, c.Has(3, 3), false)
+
+	// Stop iterating over a whole column
+	c, err = ParseA1("B:C")
+	assert.Equal(t, err, nil)
+	cells = nil
+	c.ForEachWithBreak(func(column, row int) bool {
+		cells = append(cells, ColumnName(column)+string(rune('0'+row)))
+		return len(cells) == 3
+	})
+	assert.Equal(t, cells, []string{"B1", "C1", "B2"})
+}
@@ -0,0 +1,537 @@
+package rangetype
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrAdaDeclaration = errors.New("INVALID ADA TYPE DECLARATION")
+
+// adaType checks if the given string is an Ada type declaration, like "type Volt is delta 0.125 range 0.0 .. 255.0;",
+// or a subtype indication that refers to a named type, like "Day'Range" or "Integer range 1 .. 10"
+func adaType(s string) bool {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToLower(fields[0]) {
+	case "type", "subtype":
+		return true
+	}
+	name := fields[0]
+	if pos := strings.Index(name, "'"); pos != -1 {
+		if !strings.EqualFold(name[pos+1:], "range") {
+			return false
+		}
+		name = name[:pos]
+	}
+	return adaName(name) && (len(fields) == 1 || strings.EqualFold(fields[1], "range"))
+}
+
+// adaName checks if the given string is an Ada identifier
+func adaName(s string) bool {
+	if s == "" || !unicode.IsLetter(rune(s[0])) {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// newAdaType evaluates an Ada type declaration or a subtype indication and returns a Range struct.
+// Declared types are registered, so that later declarations and expressions can refer to them.
+//
+// Integer types, enumeration types, fixed point types, floating point types, derived types and subtypes are supported:
+//
+// > type Small is range 0 .. 100;
+// > type Color is (Red, Green, Blue);
+// > type Volt is delta 0.125 range 0.0 .. 255.0;
+// > type Money is delta 0.01 digits 14;
+// > type Real is digits 6 range -1.0E6 .. 1.0E6;
+// > type Tiny is new Small range 0 .. 10;
+// > subtype Percent is Small range Small'First .. Small'Last;
+//
+// "delta" is used as the step size, while "digits" is used as the precision.
+func newAdaType(declaration string) (*Range, error) {
+	r, _, err := evalAdaType(declaration)
+	return r, err
+}
+
+// evalAdaType evaluates an Ada type declaration or a subtype indication, see newAdaType.
+// The names of the numbers are also returned, if the type is an enumeration.
+func evalAdaType(declaration string) (*Range, []string, error) {
+	name, r, names, err := parseAdaType(declaration)
+	if err != nil {
+		return nil, nil, err
+	}
+	if name != "" {
+		if err := register(name, r, names); err != nil {
+			return nil, nil, err
+		}
+	}
+	return r, names, nil
+}
+
+// parseAdaType evaluates an Ada type declaration or a subtype indication, without registering it.
+// The declared name is returned, or an empty string if it is a subtype indication.
+// The names of the numbers are also returned, if the type is an enumeration.
+func parseAdaType(declaration string) (string, *Range, []string, error) {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(declaration), ";"))
+	switch {
+	case len(fields) == 0:
+		return "", nil, nil, ErrAdaDeclaration
+	case strings.EqualFold(fields[0], "type"), strings.EqualFold(fields[0], "subtype"):
+		// A declaration, handled below
+	default:
+		r, names, err := newAdaSubtype(strings.Join(fields, " "))
+		return "", r, names, err
+	}
+	if len(fields) < 4 || !adaName(fields[1]) || !strings.EqualFold(fields[2], "is") {
+		return "", nil, nil, ErrAdaDeclaration
+	}
+	name, definition := fields[1], fields[3:]
+
+	var (
+		r     *Range
+		names []string
+		err   error
+	)
+	switch {
+	case strings.EqualFold(fields[0], "subtype"):
+		r, names, err = newAdaSubtype(strings.Join(definition, " "))
+	case strings.EqualFold(definition[0], "new"):
+		// Derived type
+		r, names, err = newAdaSubtype(strings.Join(definition[1:], " "))
+	case strings.HasPrefix(definition[0], "("):
+		// Enumeration type
+		r, names, err = newAdaEnumeration(strings.Join(definition, " "))
+	default:
+		r, err = newAdaDefinition(declaration, definition)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, r, names, nil
+}
+
+// newAdaEnumeration evaluates an enumeration type definition, like "(Red, Green, Blue)".
+// The numbers in the enumeration are from 0 and up.
+func newAdaEnumeration(definition string) (*Range, []string, error) {
+	if !strings.HasPrefix(definition, "(") || !strings.HasSuffix(definition, ")") {
+		return nil, nil, errors.New("INVALID ENUMERATION: " + definition)
+	}
+	var names []string
+	for _, name := range strings.Split(definition[1:len(definition)-1], ",") {
+		name = strings.TrimSpace(name)
+		if !adaName(name) {
+			return nil, nil, errors.New("INVALID ENUMERATION LITERAL: " + name)
+		}
+		for _, existing := range names {
+			if strings.EqualFold(existing, name) {
+				return nil, nil, errors.New("DUPLICATE ENUMERATION LITERAL: " + name)
+			}
+		}
+		names = append(names, name)
+	}
+	r := &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: 0, to: float64(len(names) - 1), step: 1}
+	return r, names, nil
+}
+
+// newAdaSubtype evaluates a subtype indication, like "Integer range 1 .. 10" or "Day'Range".
+// The names of the numbers are also returned, if the parent type is an enumeration.
+func newAdaSubtype(indication string) (*Range, []string, error) {
+	fields := strings.Fields(indication)
+	if len(fields) == 0 {
+		return nil, nil, ErrAdaDeclaration
+	}
+	name := fields[0]
+	if pos := strings.Index(name, "'"); pos != -1 && strings.EqualFold(name[pos+1:], "range") {
+		name = name[:pos]
+	}
+	parent, ok := Lookup(name)
+	if !ok {
+		return nil, nil, errors.New("UNKNOWN TYPE: " + name)
+	}
+	var names []string
+	if e, ok := LookupEnum(name); ok {
+		names = e.names
+	}
+	if len(fields) == 1 {
+		copied := *parent
+		return &copied, names, nil
+	}
+	if !strings.EqualFold(fields[1], "range") || len(fields) == 2 {
+		return nil, nil, errors.New("UNSUPPORTED CONSTRAINT: " + strings.Join(fields[1:], " "))
+	}
+	r, err := NewRange(strings.Join(fields[2:], " "), true)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The constraint must be within the parent type, like in Ada
+	if !parent.Valid(r.First()) || !parent.Valid(r.Last()) {
+		return nil, nil, fmt.Errorf("RANGE %v .. %v IS NOT WITHIN %s", r.First(), r.Last(), name)
+	}
+	r.step = parent.step
+	r.precision = parent.precision
+	return r, names, nil
+}
+
+// newAdaDefinition evaluates the part of an Ada type declaration that comes after "is",
+// like "delta 0.125 range 0.0 .. 255.0"
+func newAdaDefinition(declaration string, definition []string) (*Range, error) {
+	var delta, digits, rangeExpression string
+	for clauses := definition; len(clauses) > 0; {
+		switch strings.ToLower(clauses[0]) {
+		case "range":
+			// The range is always the last clause
+			rangeExpression = strings.Join(clauses[1:], " ")
+			clauses = nil
+		case "delta":
+			if len(clauses) < 2 {
+				return nil, errors.New("MISSING DELTA IN: " + declaration)
+			}
+			delta = clauses[1]
+			clauses = clauses[2:]
+		case "digits":
+			if len(clauses) < 2 {
+				return nil, errors.New("MISSING DIGITS IN: " + declaration)
+			}
+			digits = clauses[1]
+			clauses = clauses[2:]
+		default:
+			return nil, errors.New("UNSUPPORTED ADA TYPE DEFINITION: " + strings.Join(clauses, " "))
+		}
+	}
+
+	var (
+		r         *Range
+		err       error
+		step      float64
+		precision int
+	)
+	if rangeExpression != "" {
+		if r, err = NewRange(rangeExpression, true); err != nil {
+			return nil, err
+		}
+	}
+	if delta != "" {
+		if step, err = eval(delta, true); err != nil {
+			return nil, errors.New("INVALID DELTA: " + delta + ", " + err.Error())
+		}
+		if step <= 0 {
+			return nil, errors.New("DELTA MUST BE POSITIVE: " + delta)
+		}
+	}
+	if digits != "" {
+		n, err := eval(digits, true)
+		if err != nil {
+			return nil, errors.New("INVALID DIGITS: " + digits + ", " + err.Error())
+		}
+		if n < 1 || math.Trunc(n) != n {
+			return nil, errors.New("DIGITS MUST BE A POSITIVE INTEGER: " + digits)
+		}
+		precision = int(n)
+	}
+
+	switch {
+	case delta != "" && digits != "":
+		// Decimal fixed point type, where the number of digits gives the range if it is missing
+		if r == nil {
+			bound := (math.Pow(10, float64(precision)) - 1) * step
+			r = &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: -bound, to: bound}
+		}
+		r.step = step
+	case delta != "":
+		// Ordinary fixed point type
+		if r == nil {
+			return nil, errors.New("MISSING RANGE FOR FIXED POINT TYPE: " + declaration)
+		}
+		r.step = step
+	case digits != "":
+		// Floating point type, where the range is the one of float32 or float64 if it is missing
+		if r == nil {
+			bound := math.MaxFloat64
+			if precision <= 6 {
+				bound = math.MaxFloat32
+			}
+			r = &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: -bound, to: bound}
+		}
+		r.step = 0
+		r.precision = precision
+	case r == nil:
+		return nil, ErrAdaDeclaration
+	}
+	return r, nil
+}
+
+// adaParser is a recursive descent parser for Ada expressions
+type adaParser struct {
+	exp string
+	pos int
+}
+
+// evalAda evaluates an Ada expression
+//
+// Numbers, "+", "-", "*", "/", "**", parenthesis and attributes of registered types are supported,
+// with the same operator precedence as in Ada.
+//
+// Example expressions:
+// > -(2**7)
+// -128
+// > U8'Last + 1
+// 256
+// > Integer'Succ(41)
+// 42
+func evalAda(exp string) (float64, error) {
+	p := &adaParser{exp: exp}
+	x, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	if p.skipSpace(); p.pos < len(p.exp) {
+		return 0, errors.New("UNEXPECTED " + p.exp[p.pos:] + " IN: " + exp)
+	}
+	return x, nil
+}
+
+// skipSpace moves past any whitespace
+func (p *adaParser) skipSpace() {
+	for p.pos < len(p.exp) && unicode.IsSpace(rune(p.exp[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept moves past the given token if it is next, and returns true if it was
+func (p *adaParser) accept(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.exp[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+// expression := [+|-] term {(+|-) term}
+func (p *adaParser) expression() (float64, error) {
+	negative := p.accept("-")
+	if !negative {
+		p.accept("+")
+	}
+	x, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		x = -x
+	}
+	for {
+		switch {
+		case p.accept("+"):
+			y, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			x += y
+		case p.accept("-"):
+			y, err := p.term()
+			if err != nil {
+				return 0, err
+			}
+			x -= y
+		default:
+			return x, nil
+		}
+	}
+}
+
+// term := factor {(*|/) factor}
+func (p *adaParser) term() (float64, error) {
+	x, err := p.factor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch {
+		case p.accept("*"):
+			y, err := p.factor()
+			if err != nil {
+				return 0, err
+			}
+			x *= y
+		case p.accept("/"):
+			y, err := p.factor()
+			if err != nil {
+				return 0, err
+			}
+			if y == 0 {
+				return 0, errors.New("DIVISION BY ZERO IN: " + p.exp)
+			}
+			x /= y
+		default:
+			return x, nil
+		}
+	}
+}
+
+// factor := primary [** primary]
+func (p *adaParser) factor() (float64, error) {
+	x, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	if p.accept("**") {
+		y, err := p.primary()
+		if err != nil {
+			return 0, err
+		}
+		return math.Pow(x, y), nil
+	}
+	return x, nil
+}
+
+// primary := number | (expression) | name'attribute | enumeration literal
+func (p *adaParser) primary() (float64, error) {
+	if p.accept("(") {
+		x, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		if !p.accept(")") {
+			return 0, errors.New("Unbalanced expression: " + p.exp)
+		}
+		return x, nil
+	}
+	p.skipSpace()
+	if p.pos >= len(p.exp) {
+		return 0, errors.New("MISSING VALUE IN: " + p.exp)
+	}
+	c := rune(p.exp[p.pos])
+	switch {
+	case unicode.IsDigit(c):
+		return p.number()
+	case unicode.IsLetter(c):
+		name := p.name()
+		if p.accept("'") {
+			return p.attribute(name)
+		}
+		// An enumeration literal
+		return lookupLiteral(name)
+	}
+	return 0, errors.New("INVALID VALUE: " + p.exp[p.pos:])
+}
+
+// name reads an identifier
+func (p *adaParser) name() string {
+	start := p.pos
+	for p.pos < len(p.exp) {
+		c := rune(p.exp[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			break
+		}
+		p.pos++
+	}
+	return p.exp[start:p.pos]
+}
+
+// number reads a numeric literal, like 42, 1_000, 0.125, 1.0E6 or 16#FF#
+func (p *adaParser) number() (float64, error) {
+	start := p.pos
+	digits := func() {
+		for p.pos < len(p.exp) && (unicode.IsDigit(rune(p.exp[p.pos])) || p.exp[p.pos] == '_') {
+			p.pos++
+		}
+	}
+	digits()
+	if p.pos < len(p.exp) && p.exp[p.pos] == '#' {
+		// Based literal
+		base, err := strconv.Atoi(strings.Replace(p.exp[start:p.pos], "_", "", -1))
+		if err != nil || base < 2 || base > 16 {
+			return 0, errors.New("INVALID BASE: " + p.exp[start:p.pos])
+		}
+		end := strings.Index(p.exp[p.pos+1:], "#")
+		if end == -1 {
+			return 0, errors.New("INVALID BASED LITERAL: " + p.exp[start:])
+		}
+		literal := p.exp[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		x, err := strconv.ParseInt(strings.Replace(literal, "_", "", -1), base, 64)
+		if err != nil {
+			return 0, errors.New("INVALID BASED LITERAL: " + literal)
+		}
+		return float64(x), nil
+	}
+	// A fraction, but not the start of ".."
+	if p.pos+1 < len(p.exp) && p.exp[p.pos] == '.' && unicode.IsDigit(rune(p.exp[p.pos+1])) {
+		p.pos++
+		digits()
+	}
+	// An exponent
+	if p.pos < len(p.exp) && (p.exp[p.pos] == 'e' || p.exp[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.exp) && (p.exp[p.pos] == '+' || p.exp[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	literal := p.exp[start:p.pos]
+	x, err := strconv.ParseFloat(strings.Replace(literal, "_", "", -1), 64)
+	if err != nil {
+		return 0, errors.New("INVALID VALUE: " + literal)
+	}
+	return x, nil
+}
+
+// attribute evaluates an attribute of a registered type, like U8'Last or Day'Succ(3)
+func (p *adaParser) attribute(name string) (float64, error) {
+	r, ok := Lookup(name)
+	if !ok {
+		return 0, errors.New("UNKNOWN TYPE: " + name)
+	}
+	attribute := p.name()
+	switch strings.ToLower(attribute) {
+	case "first":
+		return r.First(), nil
+	case "last":
+		return r.Last(), nil
+	case "delta":
+		return abs(r.step), nil
+	case "digits":
+		return float64(r.precision), nil
+	case "range":
+		return 0, errors.New(name + "'Range CAN ONLY BE USED AS A RANGE")
+	case "succ", "pred", "pos", "val":
+		// Attributes that take an argument
+	default:
+		return 0, errors.New("UNKNOWN ATTRIBUTE: " + name + "'" + attribute)
+	}
+	if r.step == 0 {
+		return 0, errors.New(name + "'" + attribute + " IS NOT DEFINED FOR CONTINUOUS TYPES")
+	}
+	if !p.accept("(") {
+		return 0, errors.New("MISSING ARGUMENT FOR: " + name + "'" + attribute)
+	}
+	x, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	if !p.accept(")") {
+		return 0, errors.New("Unbalanced expression: " + p.exp)
+	}
+	switch strings.ToLower(attribute) {
+	case "succ":
+		x += abs(r.step)
+	case "pred":
+		x -= abs(r.step)
+	}
+	if !r.Valid(x) {
+		return 0, fmt.Errorf("%s'%s: %v IS NOT IN %s", name, attribute, x, name)
+	}
+	return x, nil
+}
@@ -0,0 +1,151 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestAdaRangeDeclaration(t *testing.T) {
+	Small := NewAda("type Small is range 0 .. 100;")
+	assert.Equal(t, Small.String(), "[0, 100], integer range")
+	assert.Equal(t, Small.Valid(100), true)
+	assert.Equal(t, Small.Valid(101), false)
+	assert.Equal(t, Small.Bits(), 7)
+}
+
+func TestAdaDelta(t *testing.T) {
+	Volt := NewAda("type Volt is delta 0.125 range 0.0 .. 255.0;")
+	assert.Equal(t, Volt.String(), "[0, 255], float range with step 0.125")
+	assert.Equal(t, Volt.Valid(0.125), true)
+	assert.Equal(t, Volt.Valid(254.875), true)
+	assert.Equal(t, Volt.Valid(255), true)
+	assert.Equal(t, Volt.Valid(0.1), true) // within half a step of 0.125
+	assert.Equal(t, Volt.Valid(255.125), false)
+	assert.Equal(t, Volt.Take(3), []float64{0, 0.125, 0.25})
+
+	// 2041 possible values
+	assert.Equal(t, Volt.Bits(), 11)
+
+	// Decimal fixed point, where the range comes from the number of digits
+	Money := NewAda("type Money is delta 0.01 digits 4;")
+	assert.Equal(t, Money.Valid(99.99), true)
+	assert.Equal(t, Money.Valid(-99.99), true)
+	assert.Equal(t, Money.Valid(100), false)
+	assert.Equal(t, Money.Valid(0.015), true) // half a step from 0.01 and 0.02
+	assert.Equal(t, Money.Valid(-100), false)
+}
+
+func TestAdaDigits(t *testing.T) {
+	Real := NewAda("type Real is digits 6 range -1.0E6 .. 1.0E6;")
+	assert.Equal(t, Real.String(), "[-1e+06, 1e+06], float range with 6 digits")
+	assert.Equal(t, Real.Digits(), 6)
+	assert.Equal(t, Real.Valid(123456), true)
+	assert.Equal(t, Real.Valid(1.23456), true)
+	assert.Equal(t, Real.Valid(-0.001), true)
+	assert.Equal(t, Real.Valid(1.234567), false)
+	assert.Equal(t, Real.Valid(2e6), false)
+
+	// Sign bit, 20 mantissa bits and 6 exponent bits
+	assert.Equal(t, Real.Bits(), 27)
+
+	Unit := NewAda("type Unit is digits 3 range 0.0 .. 1.0;")
+	assert.Equal(t, Unit.Valid(0.125), true)
+	assert.Equal(t, Unit.Valid(0.1255), false)
+	assert.Equal(t, Unit.Bits(), 12)
+
+	// Without a range, the range of a float32 is used for up to 6 digits
+	Float := NewAda("type Float is digits 6;")
+	assert.Equal(t, Float.Valid(3.40282e38), true)
+	assert.Equal(t, Float.Valid(1e39), false)
+}
+
+func TestAdaInvalidDeclarations(t *testing.T) {
+	for _, declaration := range []string{
+		"type Volt is delta 0.125;",
+		"type Volt is delta -0.125 range 0.0 .. 1.0;",
+		"type Real is digits 1.5;",
+		"type Real is digits;",
+		"type Real is mod 256;",
+		"type Real;",
+	} {
+		_, err := NewAda2(declaration)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestAdaExpressions(t *testing.T) {
+	for exp, expected := range map[string]float64{
+		"-(2**7)":      -128,
+		"(2**7)-1":     127,
+		"-2**7":        -128,
+		"-5-1":         -6,
+		"2+3*4":        14,
+		"(2+3)*4":      20,
+		"1_000":        1000,
+		"1.0E-3":       0.001,
+		"16#FF#":       255,
+		"U8'Last+1":    256,
+		"I8'First":     -128,
+		"U8'Last/2":    127.5,
+		"Integer'Last": float64(MaxInt),
+	} {
+		result, err := eval(exp, true)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result, expected)
+	}
+	for _, exp := range []string{"(1+2", "1+", "Nope'Last", "U8'Nope", "U8'Range", "U8'Succ", "1/0", "2 3"} {
+		_, err := eval(exp, true)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestAdaAttributes(t *testing.T) {
+	NewAda("type Weekday is range 1 .. 7;")
+	assert.Equal(t, NewAda("Weekday'First .. Weekday'Last").All(), []float64{1, 2, 3, 4, 5, 6, 7})
+	assert.Equal(t, NewAda("Weekday'Succ(3) .. Weekday'Pred(7)").All(), []float64{4, 5, 6})
+	assert.Equal(t, NewAda("Weekday'Pos(2) .. Weekday'Val(3)").All(), []float64{2, 3})
+	assert.Equal(t, NewAda("Weekday'Range").All(), []float64{1, 2, 3, 4, 5, 6, 7})
+
+	// Out of range
+	_, err := NewAda2("Weekday'Succ(7) .. 10")
+	assert.NotEqual(t, err, nil)
+	_, err = NewAda2("0 .. Weekday'Val(8)")
+	assert.NotEqual(t, err, nil)
+
+	// Exclusive stop values are taken into account
+	assert.Equal(t, NewAda("0 .. Byte'Last").Len(), uint(256))
+	assert.Equal(t, New("[0,256)").Last(), 255.0)
+	assert.Equal(t, New("(0,256)").First(), 1.0)
+
+	Volt := NewAda("type Volt is delta 0.125 range 0.0 .. 255.0;")
+	assert.Equal(t, NewAda("Volt'Succ(1.0) .. Volt'Last").First(), 1.125)
+	assert.Equal(t, Volt.Valid(NewAda("Volt'Pred(Volt'Last) .. Volt'Last").First()), true)
+}
+
+func TestAdaDefinitionsBuildOnEachOther(t *testing.T) {
+	NewAda("type Hour is range 0 .. 23;")
+	WorkHour := NewAda("subtype WorkHour is Hour range 8 .. 16;")
+	assert.Equal(t, WorkHour.First(), 8.0)
+	assert.Equal(t, WorkHour.Last(), 16.0)
+
+	// The subtype can be used in later definitions
+	Lunch := NewAda("subtype Lunch is WorkHour range WorkHour'First + 3 .. WorkHour'Succ(11);")
+	assert.Equal(t, Lunch.All(), []float64{11, 12})
+
+	// Derived types and ranges of other types
+	assert.Equal(t, NewAda("type Minute is new Integer range 0 .. 59;").Last(), 59.0)
+	assert.Equal(t, NewAda("Integer range Hour'Range").Last(), 23.0)
+	assert.Equal(t, NewAda("Minute").Last(), 59.0)
+
+	// The constraint must fit within the parent type
+	_, err := NewAda2("subtype Late is Hour range 20 .. 25;")
+	assert.NotEqual(t, err, nil)
+	_, err = NewAda2("subtype Late is Nope range 20 .. 25;")
+	assert.NotEqual(t, err, nil)
+
+	// Names are case insensitive
+	r, ok := Lookup("WORKHOUR")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, r, WorkHour)
+}
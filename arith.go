@@ -0,0 +1,312 @@
+package rangetype
+
+import (
+	"math"
+)
+
+// Interval arithmetic, for finding the range of the result of an operation on two numbers from two ranges,
+// like in a compiler that uses ranges as number types. U8 + U8 is in [0, 510], and I8 * I8 is in [-16256, 16384].
+// The result ranges are inclusive, with infinite bounds if the numbers are unbounded.
+// The step size of a result is derived from the step sizes of the operands where possible, and is 0 otherwise,
+// so that the result range contains every possible result, but may also contain other numbers.
+
+// bounds returns the smallest and largest number in the range
+func (r *Range) bounds() (lo, hi float64) {
+	first, last := r.First(), r.Last()
+	return min(first, last), max(first, last)
+}
+
+// single checks if the range contains a single number
+func (r *Range) single() bool {
+	lo, hi := r.bounds()
+	return lo == hi && !r.IsEmpty()
+}
+
+// aligned checks if all steps in the range are whole multiples of the step size, like for U8 or "0..1 step 0.25"
+func (r *Range) aligned() bool {
+	return r.alignedTo(abs(r.step))
+}
+
+// alignedTo checks if the steps in the range are whole multiples of the given step size
+func (r *Range) alignedTo(step float64) bool {
+	if step <= 0 {
+		return false
+	}
+	steps := r.anchor() / step
+	return abs(steps-math.Round(steps)) < stepTolerance
+}
+
+// integers checks if all numbers in the range are whole numbers
+func (r *Range) integers() bool {
+	if r.single() {
+		lo, _ := r.bounds()
+		return math.Trunc(lo) == lo
+	}
+	return r.step != 0 && math.Trunc(r.step) == r.step && math.Trunc(r.anchor()) == r.anchor()
+}
+
+// resultRange returns an inclusive range from lo to hi, where infinite bounds are exclusive
+func resultRange(lo, hi, step float64) *Range {
+	r := &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: lo, to: hi, step: step}
+	if math.IsInf(lo, 0) {
+		r.rangeType = (r.rangeType & ^RANGE_INCLUDE_START) | RANGE_EXCLUDE_START
+	}
+	if math.IsInf(hi, 0) {
+		r.rangeType = (r.rangeType & ^RANGE_INCLUDE_STOP) | RANGE_EXCLUDE_STOP
+	}
+	return r
+}
+
+// commonStep returns the largest step size that both step sizes are whole multiples of, or 0 if there is none.
+// A step size of -1 means that the range has a single number, which does not limit the common step size.
+func commonStep(a, b float64) float64 {
+	switch {
+	case a < 0:
+		return b
+	case b < 0:
+		return a
+	case a == 0 || b == 0:
+		return 0
+	case math.Trunc(a) == a && math.Trunc(b) == b:
+		for b != 0 {
+			a, b = b, math.Mod(a, b)
+		}
+		return a
+	}
+	if a < b {
+		a, b = b, a
+	}
+	if steps := a / b; abs(steps-math.Round(steps)) < stepTolerance {
+		return b
+	}
+	return 0
+}
+
+// stepOf returns the step size of a range as a positive number, or -1 if the range has a single number.
+// An inclusive stop value that is not a whole number of steps from the start value, like 10 in "0..10 step 4",
+// makes the step size smaller, so that both the steps and the stop value are included.
+func (r *Range) stepOf() float64 {
+	if r.single() {
+		return -1
+	}
+	if last := r.Last(); r.step != 0 && !math.IsInf(last, 0) {
+		return commonStep(abs(r.step), abs(last-r.anchor()))
+	}
+	return abs(r.step)
+}
+
+// emptyResult checks if either range is empty, in which case the result is empty too
+func emptyResult(a, b *Range) bool {
+	return a.IsEmpty() || b.IsEmpty()
+}
+
+// AddRanges returns the range of a + b, for any number a in the first range and any number b in the second range
+func AddRanges(a, b *Range) *Range {
+	if emptyResult(a, b) {
+		return empty(0)
+	}
+	alo, ahi := a.bounds()
+	blo, bhi := b.bounds()
+	step := commonStep(a.stepOf(), b.stepOf())
+	if step < 0 {
+		step = 1
+	}
+	return resultRange(alo+blo, ahi+bhi, step)
+}
+
+// NegRange returns the range of -a, for any number a in the given range
+func NegRange(a *Range) *Range {
+	if a.IsEmpty() {
+		return empty(0)
+	}
+	lo, hi := a.bounds()
+	step := a.stepOf()
+	if step < 0 {
+		step = 1
+	}
+	return resultRange(-hi, -lo, step)
+}
+
+// SubRanges returns the range of a - b, for any number a in the first range and any number b in the second range
+func SubRanges(a, b *Range) *Range {
+	return AddRanges(a, NegRange(b))
+}
+
+// AbsRange returns the range of |a|, for any number a in the given range
+func AbsRange(a *Range) *Range {
+	if a.IsEmpty() {
+		return empty(0)
+	}
+	lo, hi := a.bounds()
+	step := a.stepOf()
+	switch {
+	case step < 0:
+		return resultRange(abs(lo), abs(lo), 1)
+	case lo >= 0:
+		return resultRange(lo, hi, step)
+	case hi <= 0:
+		return NegRange(a)
+	}
+	// The numbers are mirrored around 0, so they are only on the same grid if the grid includes 0
+	if !a.alignedTo(step) {
+		step = 0
+		if a.integers() {
+			step = 1
+		}
+	}
+	return resultRange(0, max(-lo, hi), step)
+}
+
+// mul multiplies two bounds, where 0 times an infinite bound is 0
+func mul(x, y float64) float64 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return x * y
+}
+
+// MulRanges returns the range of a * b, for any number a in the first range and any number b in the second range
+func MulRanges(a, b *Range) *Range {
+	if emptyResult(a, b) {
+		return empty(0)
+	}
+	alo, ahi := a.bounds()
+	blo, bhi := b.bounds()
+	products := []float64{mul(alo, blo), mul(alo, bhi), mul(ahi, blo), mul(ahi, bhi)}
+	lo, hi := products[0], products[0]
+	for _, p := range products[1:] {
+		lo, hi = min(lo, p), max(hi, p)
+	}
+	// Multiples of a step size times multiples of another step size are multiples of the product of the step sizes
+	var step float64
+	astep, bstep := a.stepOf(), b.stepOf()
+	switch {
+	case a.single() && b.alignedTo(bstep):
+		step = abs(alo * bstep)
+	case b.single() && a.alignedTo(astep):
+		step = abs(blo * astep)
+	case a.alignedTo(astep) && b.alignedTo(bstep):
+		step = astep * bstep
+	case a.integers() && b.integers():
+		step = 1
+	}
+	if lo == hi {
+		step = 1
+	}
+	return resultRange(lo, hi, step)
+}
+
+// nonZeroParts returns the negative number and the positive number in the range that are closest to 0,
+// and if there are any negative and positive numbers. For continuous ranges that include 0,
+// the closest numbers are returned as 0, since they are arbitrarily close to 0.
+func (r *Range) nonZeroParts() (negMax, posMin float64, hasNeg, hasPos bool) {
+	lo, hi := r.bounds()
+	negMax, posMin = min(hi, 0), max(lo, 0)
+	if r.step != 0 && !r.single() {
+		// The number on the step grid that is closest to 0, from below
+		step := abs(r.step)
+		anchor := r.anchor()
+		below := anchor + math.Floor((0-anchor)/step)*step
+		if abs(below) < step*stepTolerance {
+			// 0 is on the grid
+			below = 0
+		}
+		if below == 0 {
+			negMax, posMin = min(hi, -step), max(lo, step)
+		} else {
+			negMax, posMin = min(hi, below), max(lo, below+step)
+		}
+	}
+	return negMax, posMin, lo < 0 && negMax >= lo, hi > 0 && posMin <= hi
+}
+
+// DivRanges returns the range of a / b, for any number a in the first range and any number b that is not 0 in the second range.
+// If both ranges are integer ranges, the division is an integer division that is truncated towards zero, like in Go.
+// Returns ErrDivisionByZero if the only number in the second range is 0.
+func DivRanges(a, b *Range) (*Range, error) {
+	if emptyResult(a, b) {
+		return empty(0), nil
+	}
+	integer := a.integers() && b.integers()
+	alo, ahi := a.bounds()
+	negMax, posMin, hasNeg, hasPos := b.nonZeroParts()
+	if !hasNeg && !hasPos {
+		return nil, ErrDivisionByZero
+	}
+	if (hasNeg && negMax == 0) || (hasPos && posMin == 0) {
+		// Dividing by numbers that are arbitrarily close to 0
+		return resultRange(math.Inf(-1), math.Inf(1), 0), nil
+	}
+	blo, bhi := b.bounds()
+	var divisors []float64
+	if hasNeg {
+		divisors = append(divisors, blo, negMax)
+	}
+	if hasPos {
+		divisors = append(divisors, posMin, bhi)
+	}
+	// The quotient is monotonic for divisors with the same sign, so the extremes are found at the bounds
+	var quotients []float64
+	for _, x := range []float64{alo, ahi} {
+		for _, y := range divisors {
+			q := x / y
+			if math.IsInf(x, 0) && math.IsInf(y, 0) {
+				q = math.Copysign(1, x) * math.Copysign(1, y)
+			}
+			if integer {
+				q = math.Trunc(q)
+			}
+			quotients = append(quotients, q)
+		}
+	}
+	lo, hi := quotients[0], quotients[0]
+	for _, q := range quotients[1:] {
+		lo, hi = min(lo, q), max(hi, q)
+	}
+	if integer {
+		return resultRange(lo, hi, 1), nil
+	}
+	return resultRange(lo, hi, 0), nil
+}
+
+// ModRanges returns the range of a % b, for any number a in the first range and any number b that is not 0 in the second range.
+// The result has the same sign as a, like for the % operator in Go and math.Mod.
+// Returns ErrDivisionByZero if the only number in the second range is 0.
+func ModRanges(a, b *Range) (*Range, error) {
+	if emptyResult(a, b) {
+		return empty(0), nil
+	}
+	_, _, hasNeg, hasPos := b.nonZeroParts()
+	if !hasNeg && !hasPos {
+		return nil, ErrDivisionByZero
+	}
+	alo, ahi := a.bounds()
+	blo, bhi := b.bounds()
+	// The remainder is smaller than the largest divisor
+	m := max(abs(blo), abs(bhi))
+	step := 0.0
+	if a.integers() && b.integers() {
+		m--
+		step = 1
+	}
+	lo, hi := max(alo, -m), min(ahi, m)
+	if alo >= 0 {
+		lo = 0
+	}
+	if ahi <= 0 {
+		hi = 0
+	}
+	// The remainder can not be larger than the dividend
+	return resultRange(lo, hi, step), nil
+}
+
+// SmallestContaining returns the predefined integer type with the fewest bits that contains all numbers in the range,
+// together with the name of the type, like U8 and "U8". Unsigned types are preferred over signed types of the same size.
+// Returns false if the range is not an integer range, or if no predefined type is large enough.
+func SmallestContaining(r *Range) (*Range, string, bool) {
+	t, ok := findPredefined(r)
+	if !ok {
+		return nil, "", false
+	}
+	return t.r, t.name, true
+}
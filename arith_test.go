@@ -0,0 +1,140 @@
+package rangetype
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+// boundsOf returns the first and last number in the range, and the step size
+func boundsOf(r *Range) [3]float64 {
+	return [3]float64{r.First(), r.Last(), r.step}
+}
+
+func TestArithmeticRanges(t *testing.T) {
+	divide := func(a, b *Range) *Range {
+		r, err := DivRanges(a, b)
+		assert.Equal(t, err, nil)
+		return r
+	}
+	modulo := func(a, b *Range) *Range {
+		r, err := ModRanges(a, b)
+		assert.Equal(t, err, nil)
+		return r
+	}
+	for _, test := range []struct {
+		r        *Range
+		expected [3]float64
+	}{
+		{AddRanges(U8, U8), [3]float64{0, 510, 1}},
+		{SubRanges(U8, U8), [3]float64{-255, 255, 1}},
+		{MulRanges(I8, I8), [3]float64{-16256, 16384, 1}},
+		{MulRanges(U8, U8), [3]float64{0, 65025, 1}},
+		{NegRange(I8), [3]float64{-127, 128, 1}},
+		{AbsRange(I8), [3]float64{0, 128, 1}},
+		{AbsRange(New("-10..-2")), [3]float64{2, 10, 1}},
+		{divide(I8, I8), [3]float64{-128, 128, 1}},
+		{divide(U8, New("2..4")), [3]float64{0, 127, 1}},
+		{divide(New("-10..10"), New("-2..5")), [3]float64{-10, 10, 1}},
+		{modulo(U8, New("1..10")), [3]float64{0, 9, 1}},
+		{modulo(I8, New("-5..3")), [3]float64{-4, 4, 1}},
+		{modulo(New("-100..-1"), New("1..10")), [3]float64{-9, 0, 1}},
+		{modulo(New("0..5"), New("1..10")), [3]float64{0, 5, 1}},
+
+		// Step sizes
+		{AddRanges(New("0..10 step 2"), New("0..9 step 3")), [3]float64{0, 19, 1}},
+		{AddRanges(New("0..10 step 4"), New("0..10 step 2")), [3]float64{0, 20, 2}},
+		{AddRanges(New("0..8 step 4"), New("0..12 step 6")), [3]float64{0, 20, 2}},
+		{AddRanges(New("0..1 step 0.25"), New("0..1 step 0.5")), [3]float64{0, 2, 0.25}},
+		{AddRanges(New("0..1 step 0.25"), New("0..1 step 0.1")), [3]float64{0, 2, 0}},
+		{AddRanges(New("0..8 step 4"), New("1..1")), [3]float64{1, 9, 4}},
+		{MulRanges(New("0..8 step 4"), New("0..9 step 3")), [3]float64{0, 72, 12}},
+		{MulRanges(New("0..1 step 0.5"), New("2..2")), [3]float64{0, 2, 1}},
+		{MulRanges(New("1..3 step 2"), New("1..3 step 2")), [3]float64{1, 9, 1}},
+		{NegRange(New("0..1 step 0.25")), [3]float64{-1, 0, 0.25}},
+		{AbsRange(New("-4..6 step 2")), [3]float64{0, 6, 2}},
+		{AbsRange(New("-3..3 step 2")), [3]float64{0, 3, 1}},
+		{NegRange(New("0..8 step 3")), [3]float64{-8, 0, 1}},
+		{AbsRange(New("-8..0 step 3")), [3]float64{0, 8, 1}},
+		{AbsRange(New("0..9 step 3")), [3]float64{0, 9, 3}},
+		{MulRanges(New("0..8 step 3"), New("0..5 step 2")), [3]float64{0, 40, 1}},
+
+		// Floating point ranges
+		{AddRanges(New("0..1 step 0"), New("0..1 step 0")), [3]float64{0, 2, 0}},
+		{divide(New("1..2 step 0"), New("0.5..4 step 0")), [3]float64{0.25, 4, 0}},
+		{modulo(New("0..100 step 0"), New("0..2.5 step 0")), [3]float64{0, 2.5, 0}},
+	} {
+		assert.Equal(t, boundsOf(test.r), test.expected)
+	}
+
+	// Results of adding U8 numbers are in the result range
+	sum := AddRanges(U8, U8)
+	assert.Equal(t, sum.Valid(200+255), true)
+	assert.Equal(t, sum.Valid(511), false)
+
+	// Dividing by numbers that are arbitrarily close to 0 is unbounded
+	r, err := DivRanges(New("1..2 step 0"), New("-1..1 step 0"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, math.IsInf(r.from, -1) && math.IsInf(r.to, 1), true)
+
+	// Unbounded ranges
+	r = AddRanges(NewDialect("0..", DialectRust), U8)
+	assert.Equal(t, r.First(), 0.0)
+	assert.Equal(t, math.IsInf(r.to, 1), true)
+	assert.Equal(t, r.Valid(1000000), true)
+
+	_, err = DivRanges(U8, New("0..0"))
+	assert.Equal(t, err, ErrDivisionByZero)
+	_, err = ModRanges(U8, New("0..0"))
+	assert.Equal(t, err, ErrDivisionByZero)
+
+	assert.Equal(t, AddRanges(U8, empty(0)).IsEmpty(), true)
+}
+
+func TestResultsAreInResultRanges(t *testing.T) {
+	operands := []*Range{New("0..8 step 3"), New("-8..0 step 3"), New("-9..10 step 3"), New("0..5 step 2"), New("8..0 step -3"), New("[1, 7)")}
+	for _, a := range operands {
+		neg, absolute := NegRange(a), AbsRange(a)
+		a.ForEach(func(x float64) {
+			assert.Equal(t, neg.validStep(-x), true)
+			assert.Equal(t, absolute.validStep(math.Abs(x)), true)
+		})
+		for _, b := range operands {
+			sum, product := AddRanges(a, b), MulRanges(a, b)
+			a.ForEach(func(x float64) {
+				b.ForEach(func(y float64) {
+					assert.Equal(t, sum.validStep(x+y), true)
+					assert.Equal(t, product.validStep(x*y), true)
+				})
+			})
+		}
+	}
+}
+
+func TestSmallestContaining(t *testing.T) {
+	for _, test := range []struct {
+		r    *Range
+		name string
+	}{
+		{New("0..255"), "U8"},
+		{AddRanges(U8, U8), "U16"},
+		{MulRanges(I8, I8), "I16"},
+		{New("-1..1"), "I8"},
+		{New("0..127"), "U8"},
+		{New("1000..1000"), "U16"},
+		{MulRanges(U32, U32), "U64"},
+		{SubRanges(U64, U64), "I128"},
+		{New("0..10 step 2"), "U8"},
+	} {
+		r, name, ok := SmallestContaining(test.r)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, name, test.name)
+		found, _ := Lookup(name)
+		assert.Equal(t, r, found)
+	}
+	for _, r := range []*Range{New("0..1 step 0.5"), New("0..1 step 0"), New("-2**128..0"), NewDialect("0..", DialectRust), empty(0)} {
+		_, _, ok := SmallestContaining(r)
+		assert.Equal(t, ok, false)
+	}
+}
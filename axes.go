@@ -0,0 +1,253 @@
+package rangetype
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrViewStep  = errors.New("A VIEW OF NESTED SLICES CAN ONLY HAVE A COLUMN STEP OF 1")
+	ErrEmptyAxis = errors.New("AN AXIS CAN NOT BE EMPTY, USE \":\" TO SELECT ALL POSITIONS")
+)
+
+// SliceAxes parses a multi-axis slice expression, like "1:3, ::2" in NumPy, and returns one Range per axis,
+// with the positions that are selected along that axis. Each axis is resolved against the length in the given shape,
+// like for SliceIndices. Axes that are not given select all positions.
+// An axis with a single index, like "2" or "-1", selects only that position.
+// Returns ErrEmptyAxis if an axis that is given is empty, like in "" or "1:, ".
+func SliceAxes(expression string, shape ...int) ([]*Range, error) {
+	axes, err := resolveAxes(expression, shape)
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]*Range, len(axes))
+	for i, axis := range axes {
+		ranges[i] = axis.Range()
+	}
+	return ranges, nil
+}
+
+// Range returns the selected positions as a range
+func (p slicePositions) Range() *Range {
+	if (p.step > 0 && p.start >= p.stop) || (p.step < 0 && p.start <= p.stop) {
+		return empty(float64(p.start))
+	}
+	return &Range{rangeType: RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP, from: float64(p.start), to: float64(p.stop), step: float64(p.step)}
+}
+
+// splitAxes splits a multi-axis expression at each "," that is not within brackets, like in "[0,3), ::2"
+func splitAxes(expression string) []string {
+	var (
+		axes  []string
+		depth int
+		start int
+	)
+	for i, c := range expression {
+		switch c {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				axes = append(axes, strings.TrimSpace(expression[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(axes, strings.TrimSpace(expression[start:]))
+}
+
+// resolveAxes resolves each axis of a multi-axis expression against the length in the given shape
+func resolveAxes(expression string, shape []int) ([]slicePositions, error) {
+	axes := splitAxes(expression)
+	if len(axes) > len(shape) {
+		return nil, fmt.Errorf("TOO MANY INDICES: %d AXES GIVEN FOR %d DIMENSIONS", len(axes), len(shape))
+	}
+	positions := make([]slicePositions, len(shape))
+	for i, length := range shape {
+		if i >= len(axes) {
+			positions[i] = slicePositions{0, length, 1}
+			continue
+		}
+		p, err := resolveAxis(axes[i], length)
+		if err != nil {
+			return nil, fmt.Errorf("AXIS %d: %w", i, err)
+		}
+		positions[i] = p
+	}
+	return positions, nil
+}
+
+// resolveAxis resolves a single axis, which is either a slice expression or a single index
+func resolveAxis(axis string, length int) (slicePositions, error) {
+	if axis == "" {
+		return slicePositions{}, ErrEmptyAxis
+	}
+	if strings.ContainsAny(axis, ":,") || strings.Contains(axis, "..") {
+		return resolveSlice(axis, length, false)
+	}
+	i, err := sliceIndex(axis, length)
+	if err != nil {
+		return slicePositions{}, err
+	}
+	if i < 0 || i >= length {
+		return slicePositions{}, ErrSliceBounds
+	}
+	return slicePositions{i, i + 1, 1}, nil
+}
+
+// SliceGrid can be used to slice nested slices with a multi-axis expression, like "1:3, ::2",
+// where the first axis selects rows and the second axis selects columns.
+// The columns are resolved against the length of each row. The selected elements are copied.
+// Returns an error if the given expression is invalid, or ErrEmptyAxis if an axis is empty, like in "1:, ".
+func SliceGrid[T any](grid [][]T, expression string) ([][]T, error) {
+	return sliceGrid(grid, expression, false)
+}
+
+// SliceGridView is like SliceGrid, but the returned rows share memory with the given rows,
+// so that changes to the elements are visible in both. This requires a column step of 1.
+func SliceGridView[T any](grid [][]T, expression string) ([][]T, error) {
+	return sliceGrid(grid, expression, true)
+}
+
+// sliceGrid slices nested slices with a multi-axis expression, as a view or as a copy
+func sliceGrid[T any](grid [][]T, expression string, view bool) ([][]T, error) {
+	axes := splitAxes(expression)
+	if len(axes) > 2 {
+		return nil, fmt.Errorf("TOO MANY INDICES: %d AXES GIVEN FOR 2 DIMENSIONS", len(axes))
+	}
+	rows, err := resolveAxis(axes[0], len(grid))
+	if err != nil {
+		return nil, fmt.Errorf("AXIS 0: %w", err)
+	}
+	if len(axes) == 2 && axes[1] == "" {
+		// Also when no rows are selected
+		return nil, fmt.Errorf("AXIS 1: %w", ErrEmptyAxis)
+	}
+	var result [][]T
+	for _, i := range rows.indices() {
+		columns := slicePositions{0, len(grid[i]), 1}
+		if len(axes) == 2 {
+			if columns, err = resolveAxis(axes[1], len(grid[i])); err != nil {
+				return nil, fmt.Errorf("AXIS 1: %w", err)
+			}
+		}
+		switch {
+		case view && columns.step != 1:
+			return nil, ErrViewStep
+		case view && columns.stop < columns.start:
+			result = append(result, grid[i][columns.start:columns.start])
+		case view:
+			result = append(result, grid[i][columns.start:columns.stop:columns.stop])
+		default:
+			result = append(result, pick(grid[i], columns.indices()))
+		}
+	}
+	return result, nil
+}
+
+// View is a view of a flat buffer, where the elements are laid out in row-major order, with the given shape.
+// Slicing a view returns a new view that shares the buffer.
+type View[T any] struct {
+	Data    []T
+	Offset  int
+	Shape   []int
+	Strides []int
+}
+
+// NewView returns a view of a flat row-major buffer with the given shape, like 3, 4 for 3 rows and 4 columns.
+// Returns an error if the number of elements in the buffer does not match the shape
+func NewView[T any](data []T, shape ...int) (*View[T], error) {
+	size := 1
+	strides := make([]int, len(shape))
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] < 0 {
+			return nil, fmt.Errorf("INVALID SHAPE: %v", shape)
+		}
+		strides[i] = size
+		size *= shape[i]
+	}
+	if size != len(data) {
+		return nil, fmt.Errorf("CAN NOT USE A BUFFER OF SIZE %d WITH SHAPE %v", len(data), shape)
+	}
+	return &View[T]{Data: data, Shape: append([]int(nil), shape...), Strides: strides}, nil
+}
+
+// Slice returns a view of the elements that are selected by a multi-axis expression, like "1:3, ::2".
+// The returned view shares the buffer with this view.
+// Returns an error if the given expression is invalid
+func (v *View[T]) Slice(expression string) (*View[T], error) {
+	axes, err := resolveAxes(expression, v.Shape)
+	if err != nil {
+		return nil, err
+	}
+	sliced := &View[T]{Data: v.Data, Offset: v.Offset, Shape: make([]int, len(axes)), Strides: make([]int, len(axes))}
+	for i, axis := range axes {
+		sliced.Shape[i] = len(axis.indices())
+		sliced.Strides[i] = v.Strides[i] * axis.step
+		if sliced.Shape[i] > 0 {
+			sliced.Offset += v.Strides[i] * axis.start
+		}
+	}
+	return sliced, nil
+}
+
+// Len returns the number of elements in the view
+func (v *View[T]) Len() int {
+	size := 1
+	for _, n := range v.Shape {
+		size *= n
+	}
+	return size
+}
+
+// index returns the position in the buffer for the given indices
+func (v *View[T]) index(indices []int) int {
+	if len(indices) != len(v.Shape) {
+		panic(fmt.Sprintf("%d INDICES GIVEN FOR %d DIMENSIONS", len(indices), len(v.Shape)))
+	}
+	pos := v.Offset
+	for i, j := range indices {
+		if j < 0 || j >= v.Shape[i] {
+			panic(ErrSliceBounds)
+		}
+		pos += j * v.Strides[i]
+	}
+	return pos
+}
+
+// At returns the element at the given indices, one per axis
+func (v *View[T]) At(indices ...int) T {
+	return v.Data[v.index(indices)]
+}
+
+// Set sets the element at the given indices, one per axis
+func (v *View[T]) Set(x T, indices ...int) {
+	v.Data[v.index(indices)] = x
+}
+
+// Copy returns the elements of the view as a new flat buffer, in row-major order
+func (v *View[T]) Copy() []T {
+	result := make([]T, 0, v.Len())
+	if v.Len() == 0 {
+		return result
+	}
+	indices := make([]int, len(v.Shape))
+	for {
+		result = append(result, v.At(indices...))
+		// Increase the indices, starting with the last axis
+		axis := len(indices) - 1
+		for ; axis >= 0; axis-- {
+			indices[axis]++
+			if indices[axis] < v.Shape[axis] {
+				break
+			}
+			indices[axis] = 0
+		}
+		if axis < 0 {
+			return result
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package rangetype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestSliceAxes(t *testing.T) {
+	ranges, err := SliceAxes("1:3, ::2", 4, 5)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(ranges), 2)
+	assert.Equal(t, ranges[0].All(), []float64{1, 2})
+	assert.Equal(t, ranges[1].All(), []float64{0, 2, 4})
+
+	ranges, err = SliceAxes("[0,2), -1, ::-2", 3, 4, 5)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ranges[0].All(), []float64{0, 1})
+	assert.Equal(t, ranges[1].All(), []float64{3})
+	assert.Equal(t, ranges[2].All(), []float64{4, 2, 0})
+
+	// Missing axes select everything
+	ranges, err = SliceAxes("2:", 4, 3)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ranges[0].All(), []float64{2, 3})
+	assert.Equal(t, ranges[1].All(), []float64{0, 1, 2})
+
+	ranges, err = SliceAxes("3:1, :", 4, 3)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ranges[0].IsEmpty(), true)
+	assert.Equal(t, len(ranges[0].All()), 0)
+
+	for _, exp := range []string{"1:2, 3:4, 5:6", "4, :", "1:2, a", "::0"} {
+		_, err := SliceAxes(exp, 4, 3)
+		assert.NotEqual(t, err, nil)
+	}
+
+	// Empty axes are not the same as the first position
+	for _, exp := range []string{"", "1:, ", ", 1", " "} {
+		_, err := SliceAxes(exp, 4, 3)
+		assert.Equal(t, errors.Is(err, ErrEmptyAxis), true)
+	}
+}
+
+func TestSliceGrid(t *testing.T) {
+	grid := [][]int{
+		{0, 1, 2, 3},
+		{10, 11, 12, 13},
+		{20, 21, 22, 23},
+	}
+	for _, test := range []struct {
+		exp      string
+		expected [][]int
+	}{
+		{"1:3, ::2", [][]int{{10, 12}, {20, 22}}},
+		{"::-1, -1", [][]int{{23}, {13}, {3}}},
+		{"0", [][]int{{0, 1, 2, 3}}},
+		{":, 1..2", [][]int{{1, 2}, {11, 12}, {21, 22}}},
+		{"^1.., ::-1", [][]int{{23, 22, 21, 20}}},
+	} {
+		result, err := SliceGrid(grid, test.exp)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result, test.expected)
+	}
+
+	// A copy does not share memory
+	result, err := SliceGrid(grid, "0, 0")
+	assert.Equal(t, err, nil)
+	result[0][0] = 42
+	assert.Equal(t, grid[0][0], 0)
+
+	// A view shares memory
+	view, err := SliceGridView(grid, "1:, 1:3")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, view, [][]int{{11, 12}, {21, 22}})
+	view[0][0] = 42
+	assert.Equal(t, grid[1][1], 42)
+	_ = append(view[0], 99)
+	assert.Equal(t, grid[1][3], 13)
+
+	_, err = SliceGridView(grid, ":, ::2")
+	assert.Equal(t, err, ErrViewStep)
+	_, err = SliceGrid(grid, "1, 2, 3")
+	assert.NotEqual(t, err, nil)
+	_, err = SliceGrid(grid, "5")
+	assert.NotEqual(t, err, nil)
+	for _, exp := range []string{"", "1:, ", "3:, "} {
+		_, err = SliceGrid(grid, exp)
+		assert.Equal(t, errors.Is(err, ErrEmptyAxis), true)
+		_, err = SliceGridView(grid, exp)
+		assert.Equal(t, errors.Is(err, ErrEmptyAxis), true)
+	}
+}
+
+func TestView(t *testing.T) {
+	data := []int{
+		0, 1, 2, 3,
+		10, 11, 12, 13,
+		20, 21, 22, 23,
+	}
+	v, err := NewView(data, 3, 4)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v.At(2, 1), 21)
+	assert.Equal(t, v.Copy(), data)
+
+	sub, err := v.Slice("1:3, ::2")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, sub.Shape, []int{2, 2})
+	assert.Equal(t, sub.Copy(), []int{10, 12, 20, 22})
+
+	// Views share the buffer
+	sub.Set(42, 1, 1)
+	assert.Equal(t, data[10], 42)
+	assert.Equal(t, v.At(2, 2), 42)
+
+	reversed, err := v.Slice("::-1, ::-1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, reversed.Copy(), []int{23, 42, 21, 20, 13, 12, 11, 10, 3, 2, 1, 0})
+
+	// Slicing a slice
+	column, err := reversed.Slice(":, -1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, column.Copy(), []int{20, 10, 0})
+
+	none, err := v.Slice("2:1")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, none.Len(), 0)
+	assert.Equal(t, none.Copy(), []int{})
+
+	_, err = NewView(data, 5, 2)
+	assert.NotEqual(t, err, nil)
+	_, err = v.Slice("1, 2, 3")
+	assert.NotEqual(t, err, nil)
+}
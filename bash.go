@@ -0,0 +1,87 @@
+package rangetype
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// newBashRange evaluates a Bash brace expansion range, like "{1..10}", "{1..10..2}", "{10..1}", "{001..100}" or "{a..e}".
+//
+// Like in Bash, the direction is given by the start and stop values, while only the size of the increment is used.
+// If the start or stop value has a leading zero, all numbers are zero padded to the same width when joined.
+// Ranges of single characters are joined as characters.
+func newBashRange(rangeExpression string) (*Range, error) {
+	s := strings.TrimSpace(rangeExpression)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, ErrRangeSyntax
+	}
+	elements := strings.Split(s[1:len(s)-1], "..")
+	if len(elements) < 2 || len(elements) > 3 {
+		return nil, ErrRangeSyntax
+	}
+
+	step := int64(1)
+	if len(elements) == 3 {
+		increment, err := strconv.ParseInt(elements[2], 10, 64)
+		if err != nil {
+			return nil, errors.New("INVALID INCREMENT: " + elements[2])
+		}
+		if increment < 0 {
+			increment = -increment
+		}
+		if increment != 0 {
+			step = increment
+		}
+	}
+
+	var (
+		from, to int64
+		runes    bool
+		width    int
+	)
+	if a, b, ok := bashRunes(elements[0], elements[1]); ok {
+		from, to, runes = int64(a), int64(b), true
+	} else {
+		var err error
+		if from, err = strconv.ParseInt(elements[0], 10, 64); err != nil {
+			return nil, errors.New("INVALID RANGE VALUE: " + elements[0])
+		}
+		if to, err = strconv.ParseInt(elements[1], 10, 64); err != nil {
+			return nil, errors.New("INVALID RANGE VALUE: " + elements[1])
+		}
+		if bashZeroPadded(elements[0]) || bashZeroPadded(elements[1]) {
+			width = len(elements[0])
+			if len(elements[1]) > width {
+				width = len(elements[1])
+			}
+		}
+	}
+	if from > to {
+		step = -step
+	}
+	r := newSequence(float64(from), float64(to), float64(step))
+	r.runes = runes
+	r.width = width
+	return r, nil
+}
+
+// bashRunes returns the characters, if both the start and stop values are single characters that are not digits
+func bashRunes(from, to string) (rune, rune, bool) {
+	a, aSize := utf8.DecodeRuneInString(from)
+	b, bSize := utf8.DecodeRuneInString(to)
+	if aSize == 0 || bSize == 0 || aSize != len(from) || bSize != len(to) {
+		return 0, 0, false
+	}
+	if (a >= '0' && a <= '9') || (b >= '0' && b <= '9') {
+		return 0, 0, false
+	}
+	return a, b, true
+}
+
+// bashZeroPadded checks if the given number has a leading zero, like "001" or "-05"
+func bashZeroPadded(number string) bool {
+	number = strings.TrimPrefix(number, "-")
+	return len(number) > 1 && strings.HasPrefix(number, "0")
+}
@@ -0,0 +1,49 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestBash(t *testing.T) {
+	for exp, expected := range map[string]string{
+		"{1..5}":      "1,2,3,4,5",
+		"{1..10..2}":  "1,3,5,7,9",
+		"{1..10..-3}": "1,4,7,10",
+		"{10..1..4}":  "10,6,2",
+		"{3..-2}":     "3,2,1,0,-1,-2",
+		"{1..3..0}":   "1,2,3",
+		"{7..7}":      "7",
+		"{001..005}":  "001,002,003,004,005",
+		"{1..010..3}": "001,004,007,010",
+		"{-05..3..2}": "-05,-03,-01,001,003",
+		"{098..101}":  "098,099,100,101",
+		"{0..3}":      "0,1,2,3",
+		"{a..e}":      "a,b,c,d,e",
+		"{e..a..2}":   "e,c,a",
+		"{x..z}":      "x,y,z",
+		"{α..γ}":      "α,β,γ",
+	} {
+		r, err := NewDialect2(exp, DialectBash)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, r.JoinInts(","), expected)
+	}
+
+	r := NewDialect("{001..100}", DialectBash)
+	assert.Equal(t, r.Valid(42), true)
+	assert.Equal(t, len(r.All()), 100)
+	assert.Equal(t, r.Take(2), []float64{1, 2})
+
+	// Brace expansions are also evaluated by New
+	assert.Equal(t, New("{1..10..2}"), NewDialect("{1..10..2}", DialectBash))
+	assert.Equal(t, New(" {001..100}").JoinInts(",")[:8], "001,002,")
+	assert.Equal(t, New("{a..e}").JoinInts(""), "abcde")
+	_, err := New2("{1..5")
+	assert.NotEqual(t, err, nil)
+
+	for _, exp := range []string{"1..5", "{1..5", "{1..}", "{1..2..3..4}", "{1..a}", "{ab..c}", "{1.5..3}", "{1..3..x}"} {
+		_, err := NewDialect2(exp, DialectBash)
+		assert.NotEqual(t, err, nil)
+	}
+}
@@ -0,0 +1,97 @@
+package rangetype
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// The version of the binary layout that is written by MarshalBinary
+const binaryVersion = 1
+
+// Flags for how the numbers are stored in the binary layout
+const (
+	binaryIntegralFrom = 1 << iota // the start value is stored as a varint, instead of as a float64
+	binaryIntegralTo               // the stop value is stored as a varint
+	binaryIntegralStep             // the step size is stored as a varint
+	binaryRunes                    // the range is joined as characters
+)
+
+var ErrBinaryRange = errors.New("INVALID BINARY RANGE")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, which is also used by encoding/gob.
+//
+// The layout is a version byte, a byte with the inclusive and exclusive flags, a byte with flags for
+// how the numbers are stored, the start value, the stop value and the step size, followed by the precision
+// and the zero padded width as unsigned varints. Integral numbers are stored as varints, and other numbers
+// as 8 byte IEEE 754 floats, so that a range like "[0,255]" takes 9 bytes.
+func (r Range) MarshalBinary() ([]byte, error) {
+	data := []byte{binaryVersion, byte(r.rangeType), 0}
+	for i, x := range []float64{r.from, r.to, r.step} {
+		if integral(x) {
+			data[2] |= 1 << i
+			data = binary.AppendVarint(data, int64(x))
+		} else {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(x))
+		}
+	}
+	if r.runes {
+		data[2] |= binaryRunes
+	}
+	data = binary.AppendUvarint(data, uint64(r.precision))
+	data = binary.AppendUvarint(data, uint64(r.width))
+	return data, nil
+}
+
+// integral checks if x is a whole number that can be stored as an int64, without losing the sign of -0
+func integral(x float64) bool {
+	return math.Trunc(x) == x && x >= math.MinInt64 && x < math.MaxInt64 && !(x == 0 && math.Signbit(x))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, which is also used by encoding/gob.
+// Returns ErrBinaryRange if the data is not a range that was written by MarshalBinary.
+func (r *Range) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return ErrBinaryRange
+	}
+	if data[0] != binaryVersion {
+		return errors.New("UNSUPPORTED BINARY RANGE VERSION: " + strconv.Itoa(int(data[0])))
+	}
+	const rangeTypes = RANGE_EXCLUDE_START | RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP | RANGE_INCLUDE_STOP
+	const encodings = binaryIntegralFrom | binaryIntegralTo | binaryIntegralStep | binaryRunes
+	if data[1]&^rangeTypes != 0 || data[2]&^encodings != 0 {
+		return ErrBinaryRange
+	}
+	decoded := Range{rangeType: int(data[1]), runes: (data[2] & binaryRunes) != 0}
+	pos := 3
+	for i, x := range []*float64{&decoded.from, &decoded.to, &decoded.step} {
+		if (data[2] & (1 << i)) != 0 {
+			n, size := binary.Varint(data[pos:])
+			if size <= 0 {
+				return ErrBinaryRange
+			}
+			*x = float64(n)
+			pos += size
+		} else {
+			if len(data) < pos+8 {
+				return ErrBinaryRange
+			}
+			*x = math.Float64frombits(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+		}
+	}
+	for _, x := range []*int{&decoded.precision, &decoded.width} {
+		n, size := binary.Uvarint(data[pos:])
+		if size <= 0 || n > math.MaxInt32 {
+			return ErrBinaryRange
+		}
+		*x = int(n)
+		pos += size
+	}
+	if pos != len(data) {
+		return ErrBinaryRange
+	}
+	*r = decoded
+	return nil
+}
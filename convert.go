@@ -0,0 +1,184 @@
+package rangetype
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode is how a number is rounded to the step grid of a range when converting between ranges,
+// like from a float range to an integer range, or to a range with a coarser step size
+type RoundingMode int
+
+const (
+	RoundTruncate    RoundingMode = iota // round towards zero, like when converting a float to an int in Go
+	RoundFloor                           // round towards negative infinity
+	RoundCeil                            // round towards positive infinity
+	RoundNearestEven                     // round to the nearest step, and to an even number of steps for ties
+)
+
+// roundSteps rounds a number of steps with the given rounding mode, where negative is true if the number that is
+// rounded is negative. A number of steps that is a whole number when allowing for rounding errors,
+// like for 0.3 / 0.1, is only rounded to that whole number.
+func roundSteps(steps float64, negative bool, mode RoundingMode) float64 {
+	n := math.Round(steps)
+	if abs(steps-n) < stepTolerance {
+		return n
+	}
+	switch {
+	case mode == RoundFloor, mode == RoundTruncate && !negative:
+		return math.Floor(steps)
+	case mode == RoundCeil, mode == RoundTruncate:
+		return math.Ceil(steps)
+	}
+	return math.RoundToEven(steps)
+}
+
+// decimals returns the number of decimals that are needed to write the given number, like 1 for 0.1
+func decimals(x float64) int {
+	s := strconv.FormatFloat(x, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// roundDecimals rounds x to the given number of decimals, to remove rounding errors like in 3 * 0.1
+func roundDecimals(x float64, n int) float64 {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(x, 'f', n, 64), 64)
+	if err != nil {
+		return x
+	}
+	return rounded
+}
+
+// roundToGrid rounds x to the step grid of the range, with the given rounding mode.
+// The steps are counted from 0 if 0 is on the grid, so that ties are rounded to even multiples of the step size.
+// The result is rounded to the decimals of the step size and the start value, so that 3 steps of 0.1 is 0.3.
+func (r *Range) roundToGrid(x float64, mode RoundingMode) float64 {
+	step := abs(r.step)
+	origin := r.anchor()
+	if r.aligned() {
+		origin = 0
+	}
+	n := roundSteps((x-origin)/step, x < 0, mode)
+	d := decimals(step)
+	if originDecimals := decimals(origin); originDecimals > d {
+		d = originDecimals
+	}
+	return roundDecimals(origin+n*step, d)
+}
+
+// roundDigits rounds x to the given number of significant decimal digits, with the given rounding mode
+func roundDigits(x float64, digits int, mode RoundingMode) float64 {
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+		return x
+	}
+	exponent := digits - 1 - int(math.Floor(math.Log10(abs(x))))
+	scale := math.Pow(10, float64(exponent))
+	n := roundSteps(x*scale, x < 0, mode)
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(n/scale, 'g', digits, 64), 64)
+	if err != nil {
+		return x
+	}
+	return rounded
+}
+
+// Convert converts a number from one range to another, like from an I32 field to a U16 column.
+// Returns an error if x is not in the first range, or if x is not in the second range,
+// which includes numbers that are not on the step grid of the second range, like 0.5 for U8.
+// This is stricter than Range.Valid, which also accepts numbers within half a step of a number in the range,
+// so converting 0.3 to "0..1 step 0.25" is an error, even if Valid(0.3) returns true. Use ConvertRound to round it.
+func Convert(x float64, from, to *Range) (float64, error) {
+	if !from.validStep(x) {
+		return x, fmt.Errorf("%w: %v is not in the source range, it %v", ErrOverflow, x, mustBeIn(from))
+	}
+	if !to.validStep(x) {
+		return x, fmt.Errorf("%w: %v %v", ErrOverflow, x, mustBeIn(to))
+	}
+	return x, nil
+}
+
+// ConvertRound converts a number from one range to another, like Convert, but the number is first rounded
+// to the step grid of the second range with the given rounding mode, like when converting from a float range
+// to an integer range. Returns the converted number, and an error if it is not in the second range.
+func ConvertRound(x float64, from, to *Range, mode RoundingMode) (float64, error) {
+	if !from.validStep(x) {
+		return x, fmt.Errorf("%w: %v is not in the source range, it %v", ErrOverflow, x, mustBeIn(from))
+	}
+	y := x
+	if to.step != 0 && !math.IsInf(x, 0) {
+		y = to.roundToGrid(x, mode)
+	}
+	if to.precision > 0 {
+		y = roundDigits(y, to.precision, mode)
+	}
+	if !to.validStep(y) {
+		return y, fmt.Errorf("%w: %v %v", ErrOverflow, y, mustBeIn(to))
+	}
+	return y, nil
+}
+
+// ConversionError is a number that ConvertAll could not convert, with its index
+type ConversionError struct {
+	Index int
+	Err   error
+}
+
+// Error returns a message like "INDEX 2: VALUE OUT OF RANGE: 300 must be in [0, 255]"
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("INDEX %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error for the number, so that errors.Is(err, ErrOverflow) can be used
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// ConversionErrors is a list of all the numbers that ConvertAll could not convert
+type ConversionErrors []*ConversionError
+
+// Error returns the messages for all of the numbers, separated by "; "
+func (errs ConversionErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the errors for all of the numbers, so that errors.Is(err, ErrOverflow) can be used
+func (errs ConversionErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, err := range errs {
+		unwrapped[i] = err
+	}
+	return unwrapped
+}
+
+// ConvertAll converts the numbers from one range to another, with the given rounding mode, like ConvertRound.
+// All numbers are converted, and the indices of the numbers that were changed by rounding are returned.
+// Returns ConversionErrors with every number that is not in the first or the second range.
+// These numbers are left as they are in the returned numbers.
+func ConvertAll(xs []float64, from, to *Range, mode RoundingMode) ([]float64, []int, error) {
+	converted := make([]float64, len(xs))
+	var (
+		lossy []int
+		errs  ConversionErrors
+	)
+	for i, x := range xs {
+		y, err := ConvertRound(x, from, to, mode)
+		if err != nil {
+			errs = append(errs, &ConversionError{i, err})
+			y = x
+		} else if y != x {
+			lossy = append(lossy, i)
+		}
+		converted[i] = y
+	}
+	if len(errs) > 0 {
+		return converted, lossy, errs
+	}
+	return converted, lossy, nil
+}
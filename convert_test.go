@@ -0,0 +1,166 @@
+package rangetype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestConvert(t *testing.T) {
+	x, err := Convert(1000, I32, U16)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, x, 1000.0)
+
+	for _, test := range []struct {
+		x        float64
+		from, to *Range
+	}{
+		{-1, I32, U16},
+		{70000, I32, U16},
+		{0.5, New("0..1 step 0.5"), U8},
+		{1 << 31, I64, I32},
+		{300, U8, U16}, // not in the source range
+	} {
+		_, err := Convert(test.x, test.from, test.to)
+		assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	}
+	_, err = Convert(-1, I32, U16)
+	assert.Equal(t, err.Error(), "VALUE OUT OF RANGE: -1 must be in [0, 65535]")
+
+	// Numbers must be on a step, also when Valid counts them as being within half a step
+	Quarter := New("0..1 step 0.25")
+	assert.Equal(t, Quarter.Valid(0.3), true)
+	_, err = Convert(0.3, New("0..1 step 0"), Quarter)
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	x, err = ConvertRound(0.3, New("0..1 step 0"), Quarter, RoundNearestEven)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, x, 0.25)
+}
+
+func TestConvertRound(t *testing.T) {
+	Float := New("-1000..1000 step 0")
+	for _, test := range []struct {
+		x        float64
+		mode     RoundingMode
+		expected float64
+	}{
+		{2.5, RoundTruncate, 2},
+		{-2.5, RoundTruncate, -2},
+		{2.5, RoundFloor, 2},
+		{-2.5, RoundFloor, -3},
+		{2.5, RoundCeil, 3},
+		{-2.5, RoundCeil, -2},
+		{2.5, RoundNearestEven, 2},
+		{3.5, RoundNearestEven, 4},
+		{-2.5, RoundNearestEven, -2},
+		{2.6, RoundNearestEven, 3},
+		{7, RoundFloor, 7},
+	} {
+		y, err := ConvertRound(test.x, Float, I8, test.mode)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, y, test.expected)
+	}
+
+	// Coarser step sizes
+	Volt := NewAda("type Volt is delta 0.125 range 0.0 .. 255.0;")
+	Half := New("0..255 step 0.5")
+	for _, test := range []struct {
+		x        float64
+		mode     RoundingMode
+		expected float64
+	}{
+		{0.375, RoundTruncate, 0},
+		{0.375, RoundCeil, 0.5},
+		{0.375, RoundNearestEven, 0.5},
+		{0.25, RoundNearestEven, 0},
+		{0.75, RoundNearestEven, 1},
+		{254.875, RoundFloor, 254.5},
+	} {
+		y, err := ConvertRound(test.x, Volt, Half, test.mode)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, y, test.expected)
+	}
+
+	// Rounding errors are not counted as being off the grid, and the result is on the grid
+	Tenths := New("0..1 step 0.1")
+	for _, test := range []struct {
+		x        float64
+		mode     RoundingMode
+		expected float64
+	}{
+		{0.1 + 0.2, RoundFloor, 0.3},
+		{0.1 + 0.2, RoundCeil, 0.3},
+		{0.7 * 3 / 3, RoundTruncate, 0.7},
+		{0.34, RoundCeil, 0.4},
+		{0.66, RoundFloor, 0.6},
+		{0.65, RoundNearestEven, 0.6},
+	} {
+		y, err := ConvertRound(test.x, Float, Tenths, test.mode)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, y, test.expected)
+	}
+	y, err := ConvertRound(0.3, Float, New("0.05..0.95 step 0.1"), RoundCeil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, y, 0.35)
+
+	// The rounding mode is also used for the number of significant digits
+	Real := NewAda("type Short_Real is digits 3 range -1.0E6 .. 1.0E6;")
+	for _, test := range []struct {
+		x        float64
+		mode     RoundingMode
+		expected float64
+	}{
+		{1.2345, RoundTruncate, 1.23},
+		{1.2345, RoundCeil, 1.24},
+		{-1.2345, RoundFloor, -1.24},
+		{-1.2345, RoundTruncate, -1.23},
+		{1.125, RoundNearestEven, 1.12},
+		{1.375, RoundNearestEven, 1.38},
+		{987.65, RoundFloor, 987},
+		{-987.65, RoundCeil, -987},
+		{0.1 + 0.2, RoundFloor, 0.3},
+	} {
+		y, err := ConvertRound(test.x, Float, Real, test.mode)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, y, test.expected)
+	}
+
+	// A grid that does not include 0
+	y, err = ConvertRound(4, Float, New("1..9 step 2"), RoundFloor)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, y, 3.0)
+
+	_, err = ConvertRound(255.5, Float, U8, RoundNearestEven)
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	y, err = ConvertRound(255.5, Float, U8, RoundTruncate)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, y, 255.0)
+	_, err = ConvertRound(2000, Float, U16, RoundTruncate)
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+}
+
+func TestConvertAll(t *testing.T) {
+	Float := New("-1000..1000 step 0")
+	converted, lossy, err := ConvertAll([]float64{1, 2.5, 3, -0.5, 100}, Float, I8, RoundNearestEven)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, converted, []float64{1, 2, 3, 0, 100})
+	assert.Equal(t, lossy, []int{1, 3})
+
+	converted, lossy, err = ConvertAll([]float64{1, 2, 3}, I32, U8, RoundTruncate)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, converted, []float64{1, 2, 3})
+	assert.Equal(t, len(lossy), 0)
+
+	// All numbers are converted, and every number that is out of range is reported
+	converted, lossy, err = ConvertAll([]float64{1, 2.5, 300, -1, 3.5}, Float, U8, RoundTruncate)
+	assert.Equal(t, converted, []float64{1, 2, 300, -1, 3})
+	assert.Equal(t, lossy, []int{1, 4})
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	assert.Equal(t, err.Error(), "INDEX 2: VALUE OUT OF RANGE: 300 must be in [0, 255]; INDEX 3: VALUE OUT OF RANGE: -1 must be in [0, 255]")
+	errs, ok := err.(ConversionErrors)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Index, 2)
+	assert.Equal(t, errs[1].Index, 3)
+}
@@ -0,0 +1,171 @@
+package rangetype
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CronField is a field in a cron expression, like the minute or the day of the week,
+// with the range of values that are allowed in the field, and optional names for the values.
+type CronField struct {
+	Name     string
+	Universe *Range
+	names    []string // names for the values, starting with the first value in the universe
+	wraps    bool     // if the value after the last value is also the first value, like 7 for Sunday
+}
+
+var (
+	CronMinute     = &CronField{Name: "minute", Universe: New("0..59")}
+	CronHour       = &CronField{Name: "hour", Universe: New("0..23")}
+	CronDayOfMonth = &CronField{Name: "day of month", Universe: New("1..31")}
+	CronMonth      = &CronField{Name: "month", Universe: New("1..12"), names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	CronDayOfWeek  = &CronField{Name: "day of week", Universe: New("0..6"), names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, wraps: true}
+)
+
+// CronSet is the values that are selected by a cron field, as a list of integer ranges with steps
+type CronSet []*Range
+
+// Parse parses a cron field expression, like "*/5", "1-10/2", "MON-FRI" or "0,30".
+// "*" is every value in the field, "a-b" is the values from a to b, and "/n" selects every n-th value.
+// Names, like "MON" or "JAN", can be used instead of numbers for the day of the week and the month.
+// For the day of the week, both 0 and 7 are Sunday, like in other cron implementations.
+func (f *CronField) Parse(expression string) (CronSet, error) {
+	var set CronSet
+	for _, item := range strings.Split(expression, ",") {
+		rs, err := f.parseItem(strings.TrimSpace(item))
+		if err != nil {
+			return nil, errors.New("INVALID " + strings.ToUpper(f.Name) + " FIELD: " + expression + ", " + err.Error())
+		}
+		set = append(set, rs...)
+	}
+	return set, nil
+}
+
+// parseItem parses one comma separated item of a cron field, like "1-10/2".
+// A wrapped value at the end, like 7 in "5-7", is returned as a separate range with the first value in the universe.
+func (f *CronField) parseItem(item string) ([]*Range, error) {
+	var (
+		from, to = f.Universe.from, f.Universe.to
+		step     = 1.0
+		err      error
+	)
+	values, stepString, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.ParseUint(stepString, 10, 16)
+		if err != nil || n == 0 {
+			return nil, errors.New("INVALID STEP: " + stepString)
+		}
+		step = float64(n)
+	}
+	switch left, right, isRange := strings.Cut(values, "-"); {
+	case values == "*":
+	case isRange:
+		if from, err = f.value(left); err != nil {
+			return nil, err
+		}
+		if to, err = f.value(right); err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, errors.New("INVALID RANGE: " + values)
+		}
+	default:
+		if from, err = f.value(values); err != nil {
+			return nil, err
+		}
+		// "5/15" is every 15th value, from 5 and up
+		if !hasStep || from > to {
+			to = from
+		}
+	}
+	// Let the stop value be the last value that is reached with the step size
+	to = from + math.Floor((to-from)/step)*step
+	if f.wraps && to == f.Universe.to+1 {
+		first := cronRange(f.Universe.from, f.Universe.from, 1)
+		if to -= step; from > to {
+			return []*Range{first}, nil
+		}
+		return []*Range{cronRange(from, to, step), first}, nil
+	}
+	return []*Range{cronRange(from, to, step)}, nil
+}
+
+// cronRange returns an inclusive integer range from a to b, with the given step size
+func cronRange(a, b, step float64) *Range {
+	return &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: a, to: b, step: step}
+}
+
+// value parses a number or a name in the field, and checks that it is within the universe
+func (f *CronField) value(s string) (float64, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.Universe.from + float64(i), nil
+		}
+	}
+	n, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, errors.New("INVALID VALUE: " + s)
+	}
+	if f.wraps && float64(n) == f.Universe.to+1 {
+		return float64(n), nil
+	}
+	if !f.Universe.ValidInt(int(n)) {
+		return 0, errors.New("VALUE OUT OF RANGE: " + s + " IS NOT IN " + f.Universe.String())
+	}
+	return float64(n), nil
+}
+
+// Has checks if the given value is selected
+func (set CronSet) Has(x int) bool {
+	for _, r := range set {
+		if r.validStep(float64(x)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns the selected values, in increasing order
+func (set CronSet) Values() []int {
+	seen := make(map[int]bool)
+	var values []int
+	for _, r := range set {
+		r.ForEach(func(x float64) {
+			if !seen[int(x)] {
+				seen[int(x)] = true
+				values = append(values, int(x))
+			}
+		})
+	}
+	sort.Ints(values)
+	return values
+}
+
+// Next returns the smallest selected value that is larger than the given value.
+// If there is no such value, the smallest selected value is returned, and wrapped is true,
+// which means that the next value is in the next hour, day, month or year.
+func (set CronSet) Next(after int) (next int, wrapped bool) {
+	found := false
+	for _, r := range set {
+		// The first value in this range that is larger than the given value
+		x := r.from
+		if float64(after) >= r.from {
+			x = r.from + (math.Floor((float64(after)-r.from)/r.step)+1)*r.step
+		}
+		if x <= r.to && (!found || int(x) < next) {
+			next, found = int(x), true
+		}
+	}
+	if found {
+		return next, false
+	}
+	for i, r := range set {
+		if i == 0 || int(r.from) < next {
+			next = int(r.from)
+		}
+	}
+	return next, true
+}
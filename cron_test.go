@@ -0,0 +1,104 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestCronField(t *testing.T) {
+	for _, test := range []struct {
+		field    *CronField
+		exp      string
+		expected []int
+	}{
+		{CronMinute, "*/15", []int{0, 15, 30, 45}},
+		{CronMinute, "0,30", []int{0, 30}},
+		{CronMinute, "5/20", []int{5, 25, 45}},
+		{CronHour, "1-10/2", []int{1, 3, 5, 7, 9}},
+		{CronHour, "22-23,0-2", []int{0, 1, 2, 22, 23}},
+		{CronHour, "*", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}},
+		{CronDayOfMonth, "*/10", []int{1, 11, 21, 31}},
+		{CronDayOfMonth, "1,15,1-3", []int{1, 2, 3, 15}},
+		{CronMonth, "JAN-MAR", []int{1, 2, 3}},
+		{CronMonth, "jun,Dec", []int{6, 12}},
+		{CronMonth, "*/3", []int{1, 4, 7, 10}},
+		{CronDayOfWeek, "MON-FRI", []int{1, 2, 3, 4, 5}},
+		{CronDayOfWeek, "SAT,SUN", []int{0, 6}},
+		{CronDayOfWeek, "0-6/2", []int{0, 2, 4, 6}},
+		{CronDayOfWeek, "1-7", []int{0, 1, 2, 3, 4, 5, 6}},
+		{CronDayOfWeek, "7", []int{0}},
+		{CronDayOfWeek, "5-7", []int{0, 5, 6}},
+		{CronDayOfWeek, "1-7/3", []int{0, 1, 4}},
+		{CronDayOfWeek, "0-7/2", []int{0, 2, 4, 6}},
+		{CronDayOfWeek, "7/2", []int{0}},
+		{CronDayOfWeek, "*", []int{0, 1, 2, 3, 4, 5, 6}},
+	} {
+		set, err := test.field.Parse(test.exp)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, set.Values(), test.expected)
+		for _, x := range test.expected {
+			assert.Equal(t, set.Has(x), true)
+		}
+	}
+
+	set, err := CronHour.Parse("1-10/2")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, set[0].All(), []float64{1, 3, 5, 7, 9})
+	assert.Equal(t, set.Has(10), false)
+	assert.Equal(t, set.Has(4), false)
+
+	// 7 is also Sunday
+	set, err = CronDayOfWeek.Parse("5-7")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, set.Has(0), true)
+	assert.Equal(t, set.Has(7), false)
+	next, wrapped := set.Next(6)
+	assert.Equal(t, next, 0)
+	assert.Equal(t, wrapped, true)
+
+	for _, test := range []struct {
+		field *CronField
+		exp   string
+	}{
+		{CronMinute, "60"},
+		{CronMinute, "*/0"},
+		{CronMinute, "*/x"},
+		{CronMinute, "10-5"},
+		{CronMinute, "MON"},
+		{CronHour, "-1"},
+		{CronHour, ""},
+		{CronDayOfMonth, "0"},
+		{CronMonth, "JAN-FOO"},
+		{CronDayOfWeek, "8"},
+		{CronDayOfWeek, "7-1"},
+	} {
+		_, err := test.field.Parse(test.exp)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	set, err := CronMinute.Parse("*/15")
+	assert.Equal(t, err, nil)
+	for after, expected := range map[int]int{-1: 0, 0: 15, 14: 15, 15: 30, 44: 45} {
+		next, wrapped := set.Next(after)
+		assert.Equal(t, next, expected)
+		assert.Equal(t, wrapped, false)
+	}
+	next, wrapped := set.Next(45)
+	assert.Equal(t, next, 0)
+	assert.Equal(t, wrapped, true)
+
+	set, err = CronDayOfWeek.Parse("FRI,MON-WED/2")
+	assert.Equal(t, err, nil)
+	next, wrapped = set.Next(1)
+	assert.Equal(t, next, 3)
+	assert.Equal(t, wrapped, false)
+	next, wrapped = set.Next(3)
+	assert.Equal(t, next, 5)
+	assert.Equal(t, wrapped, false)
+	next, wrapped = set.Next(5)
+	assert.Equal(t, next, 1)
+	assert.Equal(t, wrapped, true)
+}
@@ -0,0 +1,124 @@
+package rangetype
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dialect is the range syntax of a programming language
+type Dialect int
+
+const (
+	DialectDefault Dialect = iota // The syntax used by New, see the README
+	DialectAda                    // Ada, as used by NewAda
+	DialectRust                   // Rust, where ".." excludes the stop value and "..=" includes it
+	DialectSwift                  // Swift, where "..<" excludes the stop value and "..." includes it
+	DialectKotlin                 // Kotlin, with "..", "until", "downTo", "step" and ".reversed()"
+	DialectHaskell                // Haskell, like "[1,3..11]", where the step is given by the first two numbers
+	DialectMatlab                 // MATLAB, like "1:2:10", where the step is in the middle
+	DialectJulia                  // Julia, like "1:2:10" or "range(1, 10, step=2)"
+	DialectR                      // R, like "1:10", "seq(1, 10, by=2)" or "seq_len(10)"
+	DialectBash                   // Bash brace expansion, like "{1..10..2}", "{001..100}" or "{a..e}"
+)
+
+// rangeOperators are the range operators of a programming language
+type rangeOperators struct {
+	exclusive string // the operator that excludes the stop value
+	inclusive string // the operator that includes the stop value
+	open      string // the operator that can be used without a stop value
+}
+
+var (
+	rustOperators  = rangeOperators{exclusive: "..", inclusive: "..=", open: ".."}
+	swiftOperators = rangeOperators{exclusive: "..<", inclusive: "...", open: "..."}
+)
+
+// NewDialect2 evaluates the given input string in the given dialect and returns a Range struct and an error
+func NewDialect2(rangeExpression string, dialect Dialect) (*Range, error) {
+	switch dialect {
+	case DialectDefault:
+		return New2(rangeExpression)
+	case DialectAda:
+		return NewAda2(rangeExpression)
+	case DialectRust:
+		return newOperatorRange(rangeExpression, rustOperators)
+	case DialectSwift:
+		return newOperatorRange(rangeExpression, swiftOperators)
+	case DialectKotlin:
+		return newKotlinRange(rangeExpression)
+	case DialectHaskell:
+		return newHaskellRange(rangeExpression)
+	case DialectMatlab:
+		return newMatlabRange(rangeExpression)
+	case DialectJulia:
+		return newJuliaRange(rangeExpression)
+	case DialectR:
+		return newRRange(rangeExpression)
+	case DialectBash:
+		return newBashRange(rangeExpression)
+	}
+	return nil, errors.New("UNKNOWN DIALECT: " + strconv.Itoa(int(dialect)))
+}
+
+// NewDialect is the same as NewDialect2, but panics if given an invalid input string
+func NewDialect(rangeExpression string, dialect Dialect) *Range {
+	if r, err := NewDialect2(rangeExpression, dialect); err != nil {
+		panic(err)
+	} else {
+		return r
+	}
+}
+
+// newOperatorRange evaluates a range expression with a range operator between the start and stop value,
+// like "0..=255" in Rust or "0..<256" in Swift. Both values can be left out, for ranges like "5.." or "..=10".
+// A missing value means that the range has no start or stop, not that it starts at 0.
+func newOperatorRange(rangeExpression string, operators rangeOperators) (*Range, error) {
+	pos := strings.Index(rangeExpression, "..")
+	if pos == -1 {
+		return nil, ErrRangeSyntax
+	}
+	operator := ".."
+	for _, candidate := range []string{"..=", "..<", "..."} {
+		if strings.HasPrefix(rangeExpression[pos:], candidate) {
+			operator = candidate
+			break
+		}
+	}
+	if operator != operators.exclusive && operator != operators.inclusive {
+		return nil, errors.New("UNSUPPORTED RANGE OPERATOR: " + operator)
+	}
+	left := strings.TrimSpace(rangeExpression[:pos])
+	right := strings.TrimSpace(rangeExpression[pos+len(operator):])
+	if strings.Contains(right, "..") {
+		return nil, ErrRangeSyntax
+	}
+
+	var (
+		r   = &Range{rangeType: RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP, from: math.Inf(-1), to: math.Inf(1), step: 1.0}
+		err error
+	)
+	if operator == operators.inclusive {
+		r.rangeType = RANGE_INCLUDE_START | RANGE_INCLUDE_STOP
+	}
+	if left == "" {
+		r.rangeType = (r.rangeType & ^RANGE_INCLUDE_START) | RANGE_EXCLUDE_START
+	} else if r.from, err = eval(left, false); err != nil {
+		return nil, errors.New("INVALID RANGE VALUE: " + left + ", " + err.Error())
+	}
+	if right == "" {
+		if operator != operators.open {
+			return nil, ErrMissingRange
+		}
+		r.rangeType = (r.rangeType & ^RANGE_INCLUDE_STOP) | RANGE_EXCLUDE_STOP
+	} else if r.to, err = eval(right, false); err != nil {
+		return nil, errors.New("INVALID RANGE VALUE: " + right + ", " + err.Error())
+	}
+
+	// A range where the start value is after the stop value is empty
+	if r.from > r.to {
+		return empty(r.from), nil
+	}
+	return r, nil
+}
@@ -0,0 +1,83 @@
+package rangetype
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestRust(t *testing.T) {
+	assert.Equal(t, NewDialect("0..5", DialectRust).All(), []float64{0, 1, 2, 3, 4})
+	assert.Equal(t, NewDialect("0..=5", DialectRust).All(), []float64{0, 1, 2, 3, 4, 5})
+	assert.Equal(t, NewDialect("0..=255", DialectRust).Bits(), 8)
+	assert.Equal(t, NewDialect("0..2**8", DialectRust).Valid(256), false)
+	assert.Equal(t, NewDialect("-3..-1", DialectRust).All(), []float64{-3, -2})
+
+	// Empty ranges
+	assert.Equal(t, NewDialect("5..5", DialectRust).All(), []float64(nil))
+	assert.Equal(t, NewDialect("5..1", DialectRust).All(), []float64(nil))
+	assert.Equal(t, NewDialect("5..1", DialectRust).Valid(3), false)
+	assert.Equal(t, NewDialect("5..=5", DialectRust).All(), []float64{5})
+
+	// Open ranges
+	From := NewDialect("5..", DialectRust)
+	assert.Equal(t, From.String(), "[5, +Inf), integer range")
+	assert.Equal(t, From.Take(3), []float64{5, 6, 7})
+	assert.Equal(t, From.Valid(1e12), true)
+	assert.Equal(t, From.Valid(4), false)
+
+	To := NewDialect("..=10", DialectRust)
+	assert.Equal(t, To.String(), "(-Inf, 10], integer range")
+	assert.Equal(t, To.Valid(10), true)
+	assert.Equal(t, To.Valid(-1e12), true)
+	assert.Equal(t, To.Valid(11), false)
+	assert.Equal(t, To.Last(), 10.0)
+	assert.Equal(t, NewDialect("..10", DialectRust).Valid(10), false)
+
+	// Open ranges have no length, and can not be listed
+	for _, r := range []*Range{From, To} {
+		assert.Equal(t, r.Len(), MaxUint)
+		assert.Equal(t, math.IsInf(r.Len64(), 1), true)
+		assert.Equal(t, r.Bits(), -1)
+		func() {
+			defer func() {
+				assert.Equal(t, recover(), ErrUnbounded)
+			}()
+			r.All()
+		}()
+	}
+
+	Full := NewDialect("..", DialectRust)
+	assert.Equal(t, Full.Valid(-42), true)
+	assert.Equal(t, Full.Valid(0.5), false)
+	assert.Equal(t, Full.First(), math.Inf(-1))
+
+	for _, exp := range []string{"5..=", "1...10", "0..<5", "1..2..3", "1,2", "a..b"} {
+		_, err := NewDialect2(exp, DialectRust)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestSwift(t *testing.T) {
+	assert.Equal(t, NewDialect("1...10", DialectSwift).JoinInts(","), "1,2,3,4,5,6,7,8,9,10")
+	assert.Equal(t, NewDialect("0..<256", DialectSwift).Bits(), 8)
+	assert.Equal(t, NewDialect("0..<256", DialectSwift).Valid(256), false)
+	assert.Equal(t, NewDialect("0 ..< 3", DialectSwift).All(), []float64{0, 1, 2})
+	assert.Equal(t, NewDialect("5...", DialectSwift).Take(2), []float64{5, 6})
+	assert.Equal(t, NewDialect("...5", DialectSwift).Valid(5), true)
+	assert.Equal(t, NewDialect("..<5", DialectSwift).Valid(5), false)
+	assert.Equal(t, NewDialect("..<5", DialectSwift).Valid(4), true)
+
+	for _, exp := range []string{"0..5", "0..=5", "5..<", "1..<2..<3"} {
+		_, err := NewDialect2(exp, DialectSwift)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestDialects(t *testing.T) {
+	assert.Equal(t, NewDialect("0..5", DialectDefault).All(), []float64{0, 1, 2, 3, 4, 5})
+	assert.Equal(t, NewDialect("-(2**2) .. (2**2)-1", DialectAda).All(), []float64{-4, -3, -2, -1, 0, 1, 2, 3})
+	_, err := NewDialect2("0..5", Dialect(-1))
+	assert.NotEqual(t, err, nil)
+}
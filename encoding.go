@@ -0,0 +1,139 @@
+package rangetype
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Expression returns the range as a canonical range expression, like "[0,255]" or "[0,1) step 0.1",
+// that evaluates to the same range with New2. The precision, the zero padded width and if the numbers
+// are characters are added at the end, like for "[-1000000,1000000] step 0 digits 6" or "[97,101] runes".
+// A bound that is neither inclusive nor exclusive is written without a bracket, like for "1,3".
+func (r *Range) Expression() string {
+	var buf bytes.Buffer
+	if (r.rangeType & RANGE_EXCLUDE_START) != 0 {
+		buf.WriteString("(")
+	} else if (r.rangeType & RANGE_INCLUDE_START) != 0 {
+		buf.WriteString("[")
+	}
+	buf.WriteString(strconv.FormatFloat(r.from, 'f', -1, 64))
+	buf.WriteString(",")
+	buf.WriteString(strconv.FormatFloat(r.to, 'f', -1, 64))
+	if (r.rangeType & RANGE_EXCLUDE_STOP) != 0 {
+		buf.WriteString(")")
+	} else if (r.rangeType & RANGE_INCLUDE_STOP) != 0 {
+		buf.WriteString("]")
+	}
+	if r.step != 1 {
+		buf.WriteString(" step ")
+		buf.WriteString(strconv.FormatFloat(r.step, 'f', -1, 64))
+	}
+	if r.precision > 0 {
+		buf.WriteString(" digits ")
+		buf.WriteString(strconv.Itoa(r.precision))
+	}
+	if r.width > 0 {
+		buf.WriteString(" width ")
+		buf.WriteString(strconv.Itoa(r.width))
+	}
+	if r.runes {
+		buf.WriteString(" runes")
+	}
+	return buf.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, by using the canonical range expression
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.Expression()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, by evaluating a range expression
+func (r *Range) UnmarshalText(text []byte) error {
+	parsed, err := New2(string(text))
+	if err != nil {
+		return err
+	}
+	*r = *parsed
+	return nil
+}
+
+// jsonRange is the structured JSON form of a range, where missing bounds are unbounded
+type jsonRange struct {
+	From           *float64 `json:"from,omitempty"`
+	To             *float64 `json:"to,omitempty"`
+	Step           *float64 `json:"step,omitempty"`
+	StartInclusive *bool    `json:"startInclusive,omitempty"`
+	StopInclusive  *bool    `json:"stopInclusive,omitempty"`
+	Digits         int      `json:"digits,omitempty"`
+	Width          int      `json:"width,omitempty"`
+	Runes          bool     `json:"runes,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, by using a structured form, like
+// {"from":0,"to":255,"step":1,"startInclusive":true,"stopInclusive":true}.
+// Infinite bounds are left out. A range with a bound that is neither inclusive nor exclusive,
+// like "1,3", is written as a string with the range expression, since the structured form has no way to express it.
+func (r Range) MarshalJSON() ([]byte, error) {
+	if (r.rangeType&(RANGE_EXCLUDE_START|RANGE_INCLUDE_START)) == 0 || (r.rangeType&(RANGE_EXCLUDE_STOP|RANGE_INCLUDE_STOP)) == 0 {
+		return json.Marshal(r.Expression())
+	}
+	var (
+		step           = r.step
+		startInclusive = (r.rangeType & RANGE_EXCLUDE_START) == 0
+		stopInclusive  = (r.rangeType & RANGE_EXCLUDE_STOP) == 0
+		j              = jsonRange{Step: &step, StartInclusive: &startInclusive, StopInclusive: &stopInclusive, Digits: r.precision, Width: r.width, Runes: r.runes}
+	)
+	if !math.IsInf(r.from, 0) {
+		from := r.from
+		j.From = &from
+	}
+	if !math.IsInf(r.to, 0) {
+		to := r.to
+		j.To = &to
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both the structured form and a string with a range expression, like "[0,255]", are accepted.
+// In the structured form, the step size is 1 and the bounds are inclusive, unless given.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return err
+		}
+		return r.UnmarshalText([]byte(s))
+	}
+	var j jsonRange
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	parsed := Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: math.Inf(-1), to: math.Inf(1), step: 1, precision: j.Digits, width: j.Width, runes: j.Runes}
+	if j.Step != nil {
+		parsed.step = *j.Step
+	}
+	if j.From == nil || (j.StartInclusive != nil && !*j.StartInclusive) {
+		parsed.rangeType = (parsed.rangeType & ^RANGE_INCLUDE_START) | RANGE_EXCLUDE_START
+	}
+	if j.To == nil || (j.StopInclusive != nil && !*j.StopInclusive) {
+		parsed.rangeType = (parsed.rangeType & ^RANGE_INCLUDE_STOP) | RANGE_EXCLUDE_STOP
+	}
+	if j.From != nil {
+		parsed.from = *j.From
+	}
+	if j.To != nil {
+		parsed.to = *j.To
+	}
+	if j.Digits < 0 {
+		return errors.New("INVALID NUMBER OF DIGITS: " + strconv.Itoa(j.Digits))
+	}
+	if j.Width < 0 {
+		return errors.New("INVALID WIDTH: " + strconv.Itoa(j.Width))
+	}
+	*r = parsed
+	return nil
+}
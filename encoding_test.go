@@ -0,0 +1,132 @@
+package rangetype
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestExpression(t *testing.T) {
+	for exp, expected := range map[string]string{
+		"0..255":            "[0,255]",
+		"[0:10)":            "[0,10)",
+		"(2,15) step 4":     "(2,15) step 4",
+		"1..3 step 0.5":     "[1,3] step 0.5",
+		"3:1:-1":            "[3,1) step -1",
+		"-5..-1":            "[-5,-1]",
+		"0..0.00001 step 0": "[0,0.00001] step 0",
+		"1,3":               "1,3",
+		"[1,3":              "[1,3",
+		"1,3) step 0.5":     "1,3) step 0.5",
+	} {
+		r := New(exp)
+		assert.Equal(t, r.Expression(), expected)
+		parsed, err := New2(r.Expression())
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed, r)
+	}
+	// The precision, the zero padded width and characters are kept
+	for _, r := range []*Range{
+		NewAda("Integer range 1 .. 10"),
+		NewDialect("{001..100}", DialectBash),
+		NewDialect("{a..e}", DialectBash),
+		{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: -1e6, to: 1e6, precision: 6},
+	} {
+		parsed, err := New2(r.Expression())
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed, r)
+	}
+	assert.Equal(t, NewDialect("{001..100}", DialectBash).Expression(), "[1,100] width 3")
+	assert.Equal(t, NewDialect("{a..e}", DialectBash).Expression(), "[97,101] runes")
+	for _, exp := range []string{"0..1 digits 0", "0..1 width -1", "0..1 digits x"} {
+		_, err := New2(exp)
+		assert.NotEqual(t, err, nil)
+	}
+
+	for _, r := range []*Range{NewDialect("..=10", DialectRust), NewDialect("5..", DialectRust), empty(3)} {
+		parsed, err := New2(r.Expression())
+		assert.Equal(t, err, nil)
+		assert.Equal(t, parsed.Expression(), r.Expression())
+		assert.Equal(t, parsed.String(), r.String())
+	}
+}
+
+func TestText(t *testing.T) {
+	text, err := U8.MarshalText()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(text), "[0,255]")
+
+	var r Range
+	assert.Equal(t, r.UnmarshalText([]byte("(0,10] step 2")), nil)
+	assert.Equal(t, r.All(), []float64{2, 4, 6, 8, 10})
+	assert.NotEqual(t, r.UnmarshalText([]byte("0..")), nil)
+
+	// Ranges that are not pointers are also encoded
+	text, err = NewDialect("{001..100}", DialectBash).MarshalText()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, r.UnmarshalText(text), nil)
+	assert.Equal(t, r.Join(",", 0)[:8], "001,002,")
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(U8)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(data), `{"from":0,"to":255,"step":1,"startInclusive":true,"stopInclusive":true}`)
+
+	data, err = json.Marshal(NewDialect("..10", DialectRust))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(data), `{"to":10,"step":1,"startInclusive":false,"stopInclusive":false}`)
+
+	Real := NewAda("type Real is digits 6 range -1.0E6 .. 1.0E6;")
+	data, err = json.Marshal(Real)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(data), `{"from":-1000000,"to":1000000,"step":0,"startInclusive":true,"stopInclusive":true,"digits":6}`)
+	var parsed Range
+	assert.Equal(t, json.Unmarshal(data, &parsed), nil)
+	assert.Equal(t, &parsed, Real)
+
+	// Both the structured form and range expressions are accepted
+	var config struct {
+		Volume   *Range `json:"volume"`
+		Channels *Range `json:"channels"`
+		Offset   *Range `json:"offset"`
+	}
+	err = json.Unmarshal([]byte(`{
+		"volume": {"from": 0, "to": 1, "step": 0.25, "stopInclusive": false},
+		"channels": "1..16",
+		"offset": {"from": -10}
+	}`), &config)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, config.Volume.All(), []float64{0, 0.25, 0.5, 0.75})
+	assert.Equal(t, config.Channels.Last(), 16.0)
+	assert.Equal(t, config.Offset.Valid(1000000), true)
+	assert.Equal(t, config.Offset.Valid(-11), false)
+
+	data, err = json.Marshal(config)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, json.Unmarshal(data, &config), nil)
+	assert.Equal(t, config.Volume.All(), []float64{0, 0.25, 0.5, 0.75})
+
+	// Ranges that are not pointers are encoded in the same way
+	data, err = json.Marshal(struct{ R Range }{*New("1..3")})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(data), `{"R":{"from":1,"to":3,"step":1,"startInclusive":true,"stopInclusive":true}}`)
+	data, err = json.Marshal(struct{ R Range }{*NewDialect("{a..e}", DialectBash)})
+	assert.Equal(t, err, nil)
+	var value struct{ R Range }
+	assert.Equal(t, json.Unmarshal(data, &value), nil)
+	assert.Equal(t, value.R.Join("", 0), "abcde")
+
+	// Bounds that are neither inclusive nor exclusive are written as a range expression
+	data, err = json.Marshal(New("1,3"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, string(data), `"1,3"`)
+	assert.Equal(t, json.Unmarshal(data, &parsed), nil)
+	assert.Equal(t, &parsed, New("1,3"))
+	assert.Equal(t, parsed.All(), []float64{2})
+
+	for _, input := range []string{`"1..2..3"`, `{"from": "a"}`, `{"digits": -1}`, `{"width": -1}`, `42`} {
+		assert.NotEqual(t, json.Unmarshal([]byte(input), &parsed), nil)
+	}
+}
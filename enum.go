@@ -0,0 +1,104 @@
+package rangetype
+
+import (
+	"errors"
+	"strings"
+)
+
+// Enum is an enumeration type, like "type Color is (Red, Green, Blue);" in Ada.
+// It is an integer range from 0 and up, where each number has a name.
+// The range may also be a sub-range, like "Red .. Green".
+type Enum struct {
+	*Range
+	names []string
+}
+
+// NewEnum2 evaluates an Ada enumeration type declaration, or a sub-range of a registered enumeration type,
+// and returns an Enum struct and an error. Declared types are registered, like for NewAda2.
+//
+// Example declarations:
+// > type Color is (Red, Green, Blue);
+// > subtype Warm is Color range Red .. Green;
+// > Color range Green .. Blue
+func NewEnum2(declaration string) (*Enum, error) {
+	name, r, names, err := parseAdaType(declaration)
+	if err != nil {
+		return nil, err
+	}
+	if names == nil {
+		return nil, errors.New("NOT AN ENUMERATION TYPE: " + declaration)
+	}
+	if name != "" {
+		if err := register(name, r, names); err != nil {
+			return nil, err
+		}
+	}
+	return &Enum{r, names}, nil
+}
+
+// NewEnum is the same as NewEnum2, but panics if given an invalid input string
+func NewEnum(declaration string) *Enum {
+	if e, err := NewEnum2(declaration); err != nil {
+		panic(err)
+	} else {
+		return e
+	}
+}
+
+// Name returns the name of the given number, if it is in the enumeration
+func (e *Enum) Name(i int) (string, bool) {
+	if i < 0 || i >= len(e.names) || !e.ValidInt(i) {
+		return "", false
+	}
+	return e.names[i], true
+}
+
+// Value returns the number for the given name, if it is in the enumeration.
+// Names are case insensitive, like in Ada.
+func (e *Enum) Value(name string) (int, bool) {
+	for i, existing := range e.names {
+		if strings.EqualFold(existing, name) && e.ValidInt(i) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Names returns all the names in the enumeration, in order
+func (e *Enum) Names() []string {
+	var names []string
+	e.ForEachName(func(name string) {
+		names = append(names, name)
+	})
+	return names
+}
+
+// ForEachName calls the given function for each name in the enumeration
+func (e *Enum) ForEachName(f func(string)) {
+	e.ForEach(func(x float64) {
+		f(e.names[int(x)])
+	})
+}
+
+// Sub returns the part of the enumeration from and including the first name,
+// up to and including the last name, like "Red .. Green" in Ada
+func (e *Enum) Sub(first, last string) (*Enum, error) {
+	a, ok := e.Value(first)
+	if !ok {
+		return nil, errors.New("NOT IN ENUMERATION: " + first)
+	}
+	b, ok := e.Value(last)
+	if !ok {
+		return nil, errors.New("NOT IN ENUMERATION: " + last)
+	}
+	if a > b {
+		return nil, errors.New("EMPTY RANGE: " + first + " .. " + last)
+	}
+	r := &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: float64(a), to: float64(b), step: 1}
+	return &Enum{r, e.names}, nil
+}
+
+// String returns the enumeration as a string, like "(Red, Green, Blue)"
+func (e *Enum) String() string {
+	return "(" + strings.Join(e.Names(), ", ") + ")"
+}
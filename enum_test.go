@@ -0,0 +1,71 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestEnum(t *testing.T) {
+	Color := NewEnum("type Color is (Red, Green, Blue);")
+	assert.Equal(t, Color.All(), []float64{0, 1, 2})
+	assert.Equal(t, Color.String(), "(Red, Green, Blue)")
+	assert.Equal(t, Color.Names(), []string{"Red", "Green", "Blue"})
+
+	name, ok := Color.Name(1)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, name, "Green")
+	_, ok = Color.Name(3)
+	assert.Equal(t, ok, false)
+
+	i, ok := Color.Value("blue")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, i, 2)
+	_, ok = Color.Value("Purple")
+	assert.Equal(t, ok, false)
+
+	s := ""
+	Color.ForEachName(func(name string) {
+		s += name + ";"
+	})
+	assert.Equal(t, s, "Red;Green;Blue;")
+}
+
+func TestEnumSubRanges(t *testing.T) {
+	Day := NewEnum("type Day is (Mon, Tue, Wed, Thu, Fri, Sat, Sun);")
+
+	Weekend, err := Day.Sub("Sat", "Sun")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, Weekend.Names(), []string{"Sat", "Sun"})
+	_, ok := Weekend.Name(0)
+	assert.Equal(t, ok, false)
+	_, err = Day.Sub("Sun", "Sat")
+	assert.NotEqual(t, err, nil)
+
+	Midweek := NewEnum("subtype Midweek is Day range Tue .. Thu;")
+	assert.Equal(t, Midweek.String(), "(Tue, Wed, Thu)")
+	i, ok := Midweek.Value("Wed")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, i, 2)
+	_, ok = Midweek.Value("Mon")
+	assert.Equal(t, ok, false)
+
+	assert.Equal(t, NewEnum("Day range Day'Succ(Thu) .. Sun").Names(), []string{"Fri", "Sat", "Sun"})
+	assert.Equal(t, NewEnum("Midweek'Range").Names(), []string{"Tue", "Wed", "Thu"})
+	e, ok := LookupEnum("Midweek")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, e.Names(), []string{"Tue", "Wed", "Thu"})
+
+	// NewAda returns the underlying range
+	assert.Equal(t, NewAda("type Light is (Off, Dim, Bright);").All(), []float64{0, 1, 2})
+	assert.Equal(t, NewAda("Dim .. Bright").All(), []float64{1, 2})
+	assert.Equal(t, NewAda("Light'Pos(Bright) .. Light'Val(2)").All(), []float64{2})
+
+	// Not enumerations
+	_, err = NewEnum2("type Small is range 0 .. 100;")
+	assert.NotEqual(t, err, nil)
+	_, err = NewEnum2("type Bad is (A, B, a);")
+	assert.NotEqual(t, err, nil)
+	_, err = NewEnum2("subtype Late is Day range Sat .. Nope;")
+	assert.NotEqual(t, err, nil)
+}
@@ -23,7 +23,7 @@ func main() {
 	fmt.Println("99 is a valid SmallInt value:", SmallInt.Valid(99))
 	fmt.Println("100 is a valid SmallInt value:", SmallInt.Valid(100))
 
-	// How many integers are there room for?
+	// How many integers are there room for? 100, since both 0 and 99 are included
 	fmt.Printf("SmallInt can hold %d different numbers.\n", SmallInt.Len())
 	fmt.Printf("Storage required for SmallInt: a %d-bit int\n", SmallInt.Bits())
 
@@ -0,0 +1,158 @@
+package rangetype
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// RangeFlag is a command line flag with a range expression as the value, like "--ports 8000..8100".
+// It implements the flag.Value interface, and evaluates the range expressions with New2.
+//
+//	var ports rangetype.RangeFlag
+//	flag.Var(&ports, "ports", "the range of ports to listen to")
+type RangeFlag struct {
+	*Range
+}
+
+// String returns the range expression, or an empty string if no range has been set
+func (f *RangeFlag) String() string {
+	if f == nil || f.Range == nil {
+		return ""
+	}
+	return f.Expression()
+}
+
+// Set evaluates the given range expression
+func (f *RangeFlag) Set(s string) error {
+	r, err := New2(s)
+	if err != nil {
+		return err
+	}
+	f.Range = r
+	return nil
+}
+
+// mustBeIn returns an error that says that a value must be in the given range, like "must be in [1, 64]"
+func mustBeIn(r *Range) error {
+	if r.Integer() || r.step == 0 {
+		return errors.New("must be in " + r.Interval())
+	}
+	return fmt.Errorf("must be in %s, with step %v", r.Interval(), r.step)
+}
+
+// newContinuous evaluates a range expression, like New2, but if no step size is given,
+// all numbers between the bounds are in the range, like for "[0,1)" as the range of a float.
+// Bash brace expansions, like "{1..10..3}", always have a step size.
+func newContinuous(rangeExpression string) (*Range, error) {
+	r, hasStep, err := newRange(rangeExpression, false)
+	if err != nil {
+		return nil, err
+	}
+	if !hasStep {
+		r.step = 0
+	}
+	return r, nil
+}
+
+// intFlag is an integer flag value that must be within a range
+type intFlag struct {
+	p *int
+	r *Range
+}
+
+func (f *intFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	return strconv.Itoa(*f.p)
+}
+
+func (f *intFlag) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.New("parse error")
+	}
+	if !f.r.validStep(float64(i)) {
+		return mustBeIn(f.r)
+	}
+	*f.p = i
+	return nil
+}
+
+// floatFlag is a float flag value that must be within a range
+type floatFlag struct {
+	p *float64
+	r *Range
+}
+
+func (f *floatFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f.p, 'g', -1, 64)
+}
+
+func (f *floatFlag) Set(s string) error {
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return errors.New("parse error")
+	}
+	if !f.r.validStep(x) {
+		return mustBeIn(f.r)
+	}
+	*f.p = x
+	return nil
+}
+
+// flagRange evaluates the range expression for a flag, and checks the default value.
+// If continuous is true and the expression has no step size, all numbers between the bounds are in the range.
+// Panics if the range expression is invalid or if the default value is not in the range,
+// since that is a mistake in the program and not in the given arguments.
+func flagRange(name string, value float64, rangeExpression string, continuous bool) *Range {
+	newRange := New2
+	if continuous {
+		newRange = newContinuous
+	}
+	r, err := newRange(rangeExpression)
+	if err != nil {
+		panic(fmt.Sprintf("flag -%s: invalid range %q: %v", name, rangeExpression, err))
+	}
+	if !r.validStep(value) {
+		panic(fmt.Sprintf("flag -%s: default value %v %v", name, value, mustBeIn(r)))
+	}
+	return r
+}
+
+// IntFlag defines an int flag on the command line, like flag.Int, where the value must be within
+// the given range, like "1..64". Values that are not in the range are rejected when the flags are parsed,
+// with a message like "must be in [1, 64]".
+// Panics if the range expression is invalid or if the default value is not in the range.
+func IntFlag(name string, value int, rangeExpression, usage string) *int {
+	return IntFlagOn(flag.CommandLine, name, value, rangeExpression, usage)
+}
+
+// IntFlagOn is like IntFlag, but defines the flag on the given flag set
+func IntFlagOn(fs *flag.FlagSet, name string, value int, rangeExpression, usage string) *int {
+	p := new(int)
+	*p = value
+	fs.Var(&intFlag{p, flagRange(name, float64(value), rangeExpression, false)}, name, usage)
+	return p
+}
+
+// FloatFlag defines a float64 flag on the command line, like flag.Float64, where the value must be within
+// the given range, like "[0,1)". All numbers between the bounds are in the range, unless a step size is given.
+// Values that are not in the range are rejected when the flags are parsed, with a message like "must be in [0, 1)".
+// Panics if the range expression is invalid or if the default value is not in the range.
+func FloatFlag(name string, value float64, rangeExpression, usage string) *float64 {
+	return FloatFlagOn(flag.CommandLine, name, value, rangeExpression, usage)
+}
+
+// FloatFlagOn is like FloatFlag, but defines the flag on the given flag set
+func FloatFlagOn(fs *flag.FlagSet, name string, value float64, rangeExpression, usage string) *float64 {
+	p := new(float64)
+	*p = value
+	fs.Var(&floatFlag{p, flagRange(name, value, rangeExpression, true)}, name, usage)
+	return p
+}
@@ -0,0 +1,103 @@
+package rangetype
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+// newFlagSet returns a flag set that does not print errors
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestRangeFlag(t *testing.T) {
+	fs := newFlagSet()
+	var ports RangeFlag
+	fs.Var(&ports, "ports", "ports to listen to")
+	assert.Equal(t, ports.String(), "")
+	assert.Equal(t, fs.Parse([]string{"--ports", "8000..8100"}), nil)
+	assert.Equal(t, ports.Valid(8080), true)
+	assert.Equal(t, ports.Valid(8101), false)
+	assert.Equal(t, ports.String(), "[8000,8100]")
+
+	fs = newFlagSet()
+	fs.Var(&ports, "ports", "ports to listen to")
+	assert.NotEqual(t, fs.Parse([]string{"--ports", "8000.."}), nil)
+}
+
+func TestIntFlag(t *testing.T) {
+	fs := newFlagSet()
+	workers := IntFlagOn(fs, "workers", 4, "1..64", "number of workers")
+	assert.Equal(t, *workers, 4)
+	assert.Equal(t, fs.Parse([]string{"--workers", "64"}), nil)
+	assert.Equal(t, *workers, 64)
+	assert.Equal(t, fs.Lookup("workers").Value.String(), "64")
+	assert.Equal(t, fs.Lookup("workers").DefValue, "4")
+
+	for _, arg := range []string{"0", "65", "-1", "abc", "1.5"} {
+		fs := newFlagSet()
+		IntFlagOn(fs, "workers", 4, "1..64", "number of workers")
+		assert.NotEqual(t, fs.Parse([]string{"--workers", arg}), nil)
+	}
+
+	fs = newFlagSet()
+	IntFlagOn(fs, "workers", 4, "1..64", "number of workers")
+	err := fs.Parse([]string{"--workers", "100"})
+	assert.Equal(t, strings.HasSuffix(err.Error(), "must be in [1, 64]"), true)
+
+	fs = newFlagSet()
+	IntFlagOn(fs, "even", 2, "0..10 step 2", "an even number")
+	err = fs.Parse([]string{"--even", "3"})
+	assert.Equal(t, strings.HasSuffix(err.Error(), "must be in [0, 10], with step 2"), true)
+}
+
+func TestFloatFlag(t *testing.T) {
+	fs := newFlagSet()
+	ratio := FloatFlagOn(fs, "ratio", 0.5, "[0,1)", "compression ratio")
+	assert.Equal(t, fs.Parse([]string{"--ratio", "0.75"}), nil)
+	assert.Equal(t, *ratio, 0.75)
+
+	fs = newFlagSet()
+	FloatFlagOn(fs, "ratio", 0.5, "[0,1)", "compression ratio")
+	err := fs.Parse([]string{"--ratio", "1"})
+	assert.Equal(t, strings.HasSuffix(err.Error(), "must be in [0, 1)"), true)
+
+	fs = newFlagSet()
+	volume := FloatFlagOn(fs, "volume", 0, "0..1 step 0.25", "volume")
+	assert.Equal(t, fs.Parse([]string{"--volume", "0.5"}), nil)
+	assert.Equal(t, *volume, 0.5)
+	fs = newFlagSet()
+	FloatFlagOn(fs, "volume", 0, "0..1 step 0.25", "volume")
+	assert.NotEqual(t, fs.Parse([]string{"--volume", "0.3"}), nil)
+
+	// Bash brace expansions have a step size
+	fs = newFlagSet()
+	n := FloatFlagOn(fs, "n", 1, "{1..10..3}", "every third number")
+	assert.Equal(t, fs.Parse([]string{"--n", "7"}), nil)
+	assert.Equal(t, *n, 7.0)
+	fs = newFlagSet()
+	FloatFlagOn(fs, "n", 1, "{1..10..3}", "every third number")
+	assert.NotEqual(t, fs.Parse([]string{"--n", "2"}), nil)
+}
+
+func TestFlagPanics(t *testing.T) {
+	for _, f := range []func(){
+		func() { IntFlagOn(newFlagSet(), "workers", 0, "1..64", "") },
+		func() { IntFlagOn(newFlagSet(), "workers", 1, "1..", "") },
+		func() { FloatFlagOn(newFlagSet(), "ratio", 1, "[0,1)", "") },
+		func() { FloatFlagOn(newFlagSet(), "n", 1, "{1..10", "") },
+	} {
+		panicked := func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			f()
+			return false
+		}()
+		assert.Equal(t, panicked, true)
+	}
+}
@@ -1,6 +1,10 @@
 module github.com/xyproto/rangetype
 
+go 1.21
+
+require github.com/bmizerany/assert v0.0.0-20160611221934-b7ed37b82869
+
 require (
-	github.com/bmizerany/assert v0.0.0-20160611221934-b7ed37b82869
 	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/text v0.1.0 // indirect
 )
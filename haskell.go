@@ -0,0 +1,70 @@
+package rangetype
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// newHaskellRange evaluates a Haskell arithmetic sequence, like "[1..10]", "[1,3..11]", "[10,8..0]" or "[1..]".
+// The step is the difference between the first two numbers, or 1 if there is only one.
+//
+// Like in Haskell, if any of the numbers is fractional, the last number may overshoot the stop value
+// by up to half a step, so that "[0.1,0.3..1.0]" ends with 1.1. For integers, the last number is
+// the last one that does not pass the stop value, so that "[1,3..10]" ends with 9.
+func newHaskellRange(rangeExpression string) (*Range, error) {
+	s := strings.TrimSpace(rangeExpression)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") || strings.Count(s, "..") != 1 {
+		return nil, ErrRangeSyntax
+	}
+	elements := strings.SplitN(s[1:len(s)-1], "..", 2)
+	left, right := strings.TrimSpace(elements[0]), strings.TrimSpace(elements[1])
+
+	// The first number, and the second number if it is there
+	var second string
+	if strings.Count(left, ",") == 1 {
+		elements = strings.SplitN(left, ",", 2)
+		left, second = strings.TrimSpace(elements[0]), strings.TrimSpace(elements[1])
+		if second == "" {
+			return nil, ErrRangeSyntax
+		}
+	}
+	if left == "" || strings.Contains(left, ",") {
+		return nil, ErrRangeSyntax
+	}
+	from, err := eval(left, false)
+	if err != nil {
+		return nil, errors.New("INVALID RANGE VALUE: " + left + ", " + err.Error())
+	}
+	step := 1.0
+	if second != "" {
+		next, err := eval(second, false)
+		if err != nil {
+			return nil, errors.New("INVALID RANGE VALUE: " + second + ", " + err.Error())
+		}
+		step = next - from
+		if step == 0 {
+			return nil, errors.New("STEP SIZE CAN NOT BE ZERO: " + s)
+		}
+	}
+
+	// An infinite sequence
+	if right == "" {
+		return &Range{rangeType: RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP, from: from, to: math.Inf(1) * step, step: step}, nil
+	}
+
+	limit, err := eval(right, false)
+	if err != nil {
+		return nil, errors.New("INVALID RANGE VALUE: " + right + ", " + err.Error())
+	}
+	if strings.ContainsAny(left+second+right, ".eE") {
+		// Fractional numbers, which may overshoot the limit by up to half a step
+		limit += step / 2
+	}
+	// The number of whole steps from the start value that do not pass the limit
+	steps := math.Floor((limit-from)/step + stepTolerance)
+	if steps < 0 {
+		return empty(from), nil
+	}
+	return &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: from, to: from + steps*step, step: step}, nil
+}
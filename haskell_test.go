@@ -0,0 +1,52 @@
+package rangetype
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestHaskell(t *testing.T) {
+	for exp, expected := range map[string][]float64{
+		"[1..5]":           {1, 2, 3, 4, 5},
+		"[1,3..11]":        {1, 3, 5, 7, 9, 11},
+		"[1,3..10]":        {1, 3, 5, 7, 9},
+		"[10,8..0]":        {10, 8, 6, 4, 2, 0},
+		"[10,7..0]":        {10, 7, 4, 1},
+		"[-3..-1]":         {-3, -2, -1},
+		"[ 5 , 4 .. 3 ]":   {5, 4, 3},
+		"[5..1]":           nil,
+		"[1,2..0]":         nil,
+		"[3..3]":           {3},
+		"[1.0..2.5]":       {1, 2, 3},
+		"[1.0..2.4]":       {1, 2},
+		"[1.0..0.6]":       {1},
+		"[0,0.5..1.2]":     {0, 0.5, 1},
+		"[0,0.5..1.25]":    {0, 0.5, 1, 1.5},
+		"[1.0,0.75..0.25]": {1, 0.75, 0.5, 0.25},
+	} {
+		r, err := NewDialect2(exp, DialectHaskell)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, r.All(), expected)
+	}
+
+	// The numbers overshoot by up to half a step, and are not repeated at the end
+	s := NewDialect("[0.1,0.3..1.0]", DialectHaskell).Join(" ", 1)
+	assert.Equal(t, s, "0.1 0.3 0.5 0.7 0.9 1.1")
+
+	// Infinite sequences
+	r := NewDialect("[1..]", DialectHaskell)
+	assert.Equal(t, r.Take(3), []float64{1, 2, 3})
+	assert.Equal(t, r.Valid(1e9), true)
+	r = NewDialect("[10,7..]", DialectHaskell)
+	assert.Equal(t, r.Take(4), []float64{10, 7, 4, 1})
+	assert.Equal(t, r.Last(), math.Inf(-1))
+	assert.Equal(t, r.Valid(-2), true)
+	assert.Equal(t, r.Valid(-3), false)
+
+	for _, exp := range []string{"1..5", "[1,1..5]", "[1,..5]", "[..5]", "[1,2,3..5]", "[1..2..3]", "[a..b]"} {
+		_, err := NewDialect2(exp, DialectHaskell)
+		assert.NotEqual(t, err, nil)
+	}
+}
@@ -0,0 +1,202 @@
+package rangetype
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrRangeHeader   = errors.New("INVALID RANGE HEADER")
+	ErrUnsatisfiable = errors.New("RANGE NOT SATISFIABLE")
+)
+
+// ParseByteRanges parses the value of an HTTP Range header, like "bytes=0-499, 1000-, -200" (RFC 9110),
+// and returns inclusive integer ranges of byte positions, resolved against the given content length.
+// "1000-" is from position 1000 to the end, and "-200" is the last 200 bytes.
+//
+// The ranges are sorted, and ranges that overlap or are adjacent are merged.
+// Ranges that start after the end of the content are left out, and if no ranges are left, ErrUnsatisfiable is returned.
+// Returns ErrRangeHeader if the header is invalid or has no ranges, like "bytes=", in which case it should be ignored.
+func ParseByteRanges(header string, size int64) ([]*Range, error) {
+	unit, set, found := strings.Cut(strings.TrimSpace(header), "=")
+	if !found || !strings.EqualFold(strings.TrimSpace(unit), "bytes") {
+		return nil, ErrRangeHeader
+	}
+	type span struct{ first, last int64 }
+	var (
+		spans       []span
+		specs       int
+		satisfiable bool
+	)
+	for _, spec := range strings.Split(set, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			// Empty list elements are allowed
+			continue
+		}
+		specs++
+		left, right, found := strings.Cut(spec, "-")
+		if !found || !digitsOnly(right) || !digitsOnly(left) || (left == "" && right == "") {
+			return nil, ErrRangeHeader
+		}
+		var s span
+		if left == "" {
+			// A suffix range, like "-200"
+			n, err := strconv.ParseInt(right, 10, 64)
+			if err != nil {
+				return nil, ErrRangeHeader
+			}
+			if n == 0 || size == 0 {
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			s = span{size - n, size - 1}
+		} else {
+			first, err := strconv.ParseInt(left, 10, 64)
+			if err != nil {
+				return nil, ErrRangeHeader
+			}
+			last := size - 1
+			if right != "" {
+				if last, err = strconv.ParseInt(right, 10, 64); err != nil {
+					return nil, ErrRangeHeader
+				}
+				if last < first {
+					return nil, ErrRangeHeader
+				}
+			}
+			if first >= size {
+				continue
+			}
+			if last >= size {
+				last = size - 1
+			}
+			s = span{first, last}
+		}
+		satisfiable = true
+		spans = append(spans, s)
+	}
+	if specs == 0 {
+		// At least one range is required, like for "bytes=0-499"
+		return nil, ErrRangeHeader
+	}
+	if !satisfiable {
+		return nil, ErrUnsatisfiable
+	}
+
+	// Sort the ranges, and merge the ones that overlap or are adjacent
+	sort.Slice(spans, func(i, j int) bool { return spans[i].first < spans[j].first })
+	merged := spans[:1]
+	for _, s := range spans[1:] {
+		current := &merged[len(merged)-1]
+		if s.first <= current.last+1 {
+			if s.last > current.last {
+				current.last = s.last
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	ranges := make([]*Range, len(merged))
+	for i, s := range merged {
+		ranges[i] = &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: float64(s.first), to: float64(s.last), step: 1}
+	}
+	return ranges, nil
+}
+
+// digitsOnly checks if the given string only contains the digits 0 to 9
+func digitsOnly(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ContentRange returns the value of a Content-Range header for the given byte range and content length,
+// like "bytes 0-499/1234"
+func ContentRange(r *Range, size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", int64(r.from), int64(r.to), size)
+}
+
+// ServeByteRanges writes the content as a response to the given request, while respecting the Range header.
+//
+// If there is no valid Range header, all of the content is written with status 200.
+// If the Range header can not be satisfied, status 416 is written.
+// If one range is requested, it is written with status 206 and a Content-Range header,
+// and if more than one range is requested, they are written as a multipart/byteranges response.
+func ServeByteRanges(w http.ResponseWriter, req *http.Request, content io.ReaderAt, size int64, contentType string) error {
+	w.Header().Set("Accept-Ranges", "bytes")
+	header := req.Header.Get("Range")
+	var (
+		ranges []*Range
+		err    error
+	)
+	if header != "" {
+		ranges, err = ParseByteRanges(header, size)
+	}
+	switch {
+	case err == ErrUnsatisfiable:
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return nil
+	case header == "" || err != nil:
+		// Invalid Range headers are ignored
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		w.WriteHeader(http.StatusOK)
+		return writeSection(w, req, content, 0, size)
+	case len(ranges) == 1:
+		r := ranges[0]
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Range", ContentRange(r, size))
+		w.Header().Set("Content-Length", strconv.FormatInt(int64(r.to-r.from)+1, 10))
+		w.WriteHeader(http.StatusPartialContent)
+		return writeSection(w, req, content, int64(r.from), int64(r.to-r.from)+1)
+	}
+	return WriteByteRanges(w, req, ranges, content, size, contentType)
+}
+
+// WriteByteRanges writes the given byte ranges of the content as a multipart/byteranges response, with status 206.
+// Each part has the given content type and a Content-Range header.
+func WriteByteRanges(w http.ResponseWriter, req *http.Request, ranges []*Range, content io.ReaderAt, size int64, contentType string) error {
+	mw := multipart.NewWriter(w)
+	w.Header().Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	w.WriteHeader(http.StatusPartialContent)
+	if req.Method == http.MethodHead {
+		return nil
+	}
+	for _, r := range ranges {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":  {contentType},
+			"Content-Range": {ContentRange(r, size)},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, io.NewSectionReader(content, int64(r.from), int64(r.to-r.from)+1)); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+// writeSection writes n bytes of the content, from the given offset, unless the request is a HEAD request
+func writeSection(w io.Writer, req *http.Request, content io.ReaderAt, offset, n int64) error {
+	if req.Method == http.MethodHead {
+		return nil
+	}
+	_, err := io.Copy(w, io.NewSectionReader(content, offset, n))
+	return err
+}
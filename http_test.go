@@ -0,0 +1,123 @@
+package rangetype
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestParseByteRanges(t *testing.T) {
+	for header, expected := range map[string][][2]float64{
+		"bytes=0-499":              {{0, 499}},
+		"bytes=0-499, 1000-, -200": {{0, 499}, {800, 999}},
+		"bytes=0-499, 1000-":       {{0, 499}},
+		"bytes=500-":               {{500, 999}},
+		"bytes=-200":               {{800, 999}},
+		"bytes=-2000":              {{0, 999}},
+		"bytes=900-2000":           {{900, 999}},
+		"bytes=0-0,-1":             {{0, 0}, {999, 999}},
+		"bytes=500-600,601-999":    {{500, 999}},
+		"bytes=500-700, 100-200":   {{100, 200}, {500, 700}},
+		"bytes=0-10, 5-20, 19-30":  {{0, 30}},
+		"BYTES = 1-2 ,, 4-5":       {{1, 2}, {4, 5}},
+		"bytes=5000-6000, 10-19":   {{10, 19}},
+	} {
+		ranges, err := ParseByteRanges(header, 1000)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, len(ranges), len(expected))
+		for i, r := range ranges {
+			assert.Equal(t, []float64{r.First(), r.Last()}, []float64{expected[i][0], expected[i][1]})
+		}
+	}
+
+	ranges, err := ParseByteRanges("bytes=10-12", 1000)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ranges[0].All(), []float64{10, 11, 12})
+	assert.Equal(t, ranges[0].Valid(13), false)
+	assert.Equal(t, ContentRange(ranges[0], 1000), "bytes 10-12/1000")
+
+	for _, header := range []string{"bytes=1000-", "bytes=1000-2000", "bytes=-0", "bytes=2000-,-0"} {
+		_, err := ParseByteRanges(header, 1000)
+		assert.Equal(t, err, ErrUnsatisfiable)
+	}
+	_, err = ParseByteRanges("bytes=-10", 0)
+	assert.Equal(t, err, ErrUnsatisfiable)
+
+	for _, header := range []string{"", "bytes", "items=0-1", "bytes=a-b", "bytes=5-1", "bytes=-", "bytes=1", "bytes=-1-2", "bytes=+1-2", "bytes=", "bytes= , ,"} {
+		_, err := ParseByteRanges(header, 1000)
+		assert.Equal(t, err, ErrRangeHeader)
+	}
+}
+
+func TestServeByteRanges(t *testing.T) {
+	content := "0123456789abcdefghij"
+	serve := func(method, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/", nil)
+		if header != "" {
+			req.Header.Set("Range", header)
+		}
+		rec := httptest.NewRecorder()
+		err := ServeByteRanges(rec, req, strings.NewReader(content), int64(len(content)), "text/plain")
+		assert.Equal(t, err, nil)
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "")
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.Equal(t, rec.Body.String(), content)
+	assert.Equal(t, rec.Header().Get("Accept-Ranges"), "bytes")
+
+	rec = serve(http.MethodGet, "bytes=5-9")
+	assert.Equal(t, rec.Code, http.StatusPartialContent)
+	assert.Equal(t, rec.Body.String(), "56789")
+	assert.Equal(t, rec.Header().Get("Content-Range"), "bytes 5-9/20")
+	assert.Equal(t, rec.Header().Get("Content-Length"), "5")
+
+	rec = serve(http.MethodGet, "bytes=-3")
+	assert.Equal(t, rec.Code, http.StatusPartialContent)
+	assert.Equal(t, rec.Body.String(), "hij")
+
+	rec = serve(http.MethodGet, "bytes=100-")
+	assert.Equal(t, rec.Code, http.StatusRequestedRangeNotSatisfiable)
+	assert.Equal(t, rec.Header().Get("Content-Range"), "bytes */20")
+
+	// Invalid headers are ignored
+	rec = serve(http.MethodGet, "bytes=9-5")
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.Equal(t, rec.Body.String(), content)
+
+	rec = serve(http.MethodHead, "bytes=5-9")
+	assert.Equal(t, rec.Code, http.StatusPartialContent)
+	assert.Equal(t, rec.Body.Len(), 0)
+
+	rec = serve(http.MethodGet, "bytes=0-1, 10-, -2, 3-3")
+	assert.Equal(t, rec.Code, http.StatusPartialContent)
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, mediaType, "multipart/byteranges")
+	mr := multipart.NewReader(rec.Body, params["boundary"])
+	var (
+		bodies        []string
+		contentRanges []string
+	)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		assert.Equal(t, err, nil)
+		assert.Equal(t, part.Header.Get("Content-Type"), "text/plain")
+		contentRanges = append(contentRanges, part.Header.Get("Content-Range"))
+		data, err := io.ReadAll(part)
+		assert.Equal(t, err, nil)
+		bodies = append(bodies, string(data))
+	}
+	assert.Equal(t, bodies, []string{"01", "3", "abcdefghij"})
+	assert.Equal(t, contentRanges, []string{"bytes 0-1/20", "bytes 3-3/20", "bytes 10-19/20"})
+}
@@ -0,0 +1,118 @@
+package rangetype
+
+import (
+	"errors"
+	"strings"
+)
+
+// newKotlinRange evaluates a Kotlin range or progression, like "0 until 10 step 2", "10 downTo 1 step 3"
+// or "(1..10).reversed()". Like in Kotlin, "..", "downTo" and "step" give stop values that are a whole number
+// of steps away from the start value, so that "1..10 step 4" is 1, 5 and 9.
+func newKotlinRange(rangeExpression string) (*Range, error) {
+	s := strings.TrimSpace(rangeExpression)
+
+	// The last "step" applies to everything before it, like in "(1..10).reversed() step 2"
+	if pos := lastOutsideParenthesis(s, " step "); pos != -1 {
+		r, err := newKotlinRange(s[:pos])
+		if err != nil {
+			return nil, err
+		}
+		step, err := eval(strings.TrimSpace(s[pos+len(" step "):]), false)
+		if err != nil {
+			return nil, errors.New("INVALID STEP SIZE: " + s[pos+len(" step "):] + ", " + err.Error())
+		}
+		if step <= 0 {
+			return nil, errors.New("STEP MUST BE POSITIVE: " + s[pos+len(" step "):])
+		}
+		if r.step < 0 {
+			step = -step
+		}
+		return kotlinProgression(r, step), nil
+	}
+
+	if strings.HasSuffix(s, ".reversed()") {
+		r, err := newKotlinRange(s[:len(s)-len(".reversed()")])
+		if err != nil {
+			return nil, err
+		}
+		if r.from == r.to && (r.rangeType&RANGE_EXCLUDE_STOP) != 0 {
+			// Empty
+			return r, nil
+		}
+		return &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: r.Last(), to: r.First(), step: -r.step}, nil
+	}
+
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		return newKotlinRange(s[1 : len(s)-1])
+	}
+
+	// The operators, and if they include the stop value and if they count down
+	for _, operator := range []struct {
+		token     string
+		inclusive bool
+		down      bool
+	}{
+		{" downTo ", true, true},
+		{" until ", false, false},
+		{"..<", false, false},
+		{"..", true, false},
+	} {
+		pos := strings.Index(s, operator.token)
+		if pos == -1 {
+			continue
+		}
+		from, err := eval(strings.TrimSpace(s[:pos]), false)
+		if err != nil {
+			return nil, errors.New("INVALID RANGE VALUE: " + s[:pos] + ", " + err.Error())
+		}
+		to, err := eval(strings.TrimSpace(s[pos+len(operator.token):]), false)
+		if err != nil {
+			return nil, errors.New("INVALID RANGE VALUE: " + s[pos+len(operator.token):] + ", " + err.Error())
+		}
+		r := &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: from, to: to, step: 1}
+		if !operator.inclusive {
+			r.rangeType = RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP
+		}
+		if operator.down {
+			r.step = -1
+		}
+		return kotlinProgression(r, r.step), nil
+	}
+	return nil, ErrRangeSyntax
+}
+
+// kotlinProgression returns the given range with the given step, where an inclusive stop value is
+// moved to the last number that is a whole number of steps away from the start value.
+// If the stop value is before the start value, the progression is empty, like in Kotlin.
+func kotlinProgression(r *Range, step float64) *Range {
+	if (step > 0 && r.from > r.to) || (step < 0 && r.from < r.to) {
+		return empty(r.from)
+	}
+	if r.from == r.to && (r.rangeType&RANGE_EXCLUDE_STOP) != 0 {
+		return empty(r.from)
+	}
+	progression := &Range{rangeType: r.rangeType, from: r.from, to: r.to, step: step}
+	if (r.rangeType & RANGE_INCLUDE_STOP) != 0 {
+		steps := int((r.to-r.from)/step + stepTolerance)
+		progression.to = r.from + float64(steps)*step
+	}
+	return progression
+}
+
+// lastOutsideParenthesis returns the position of the last occurrence of sep in s
+// that is not within parenthesis, or -1 if there is none
+func lastOutsideParenthesis(s, sep string) int {
+	depth := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		switch s[i] {
+		case ')':
+			depth++
+		case '(':
+			depth--
+		}
+		if depth == 0 && strings.HasPrefix(s[i:], sep) {
+			return i
+		}
+	}
+	return -1
+}
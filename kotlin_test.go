@@ -0,0 +1,51 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestKotlin(t *testing.T) {
+	for exp, expected := range map[string][]float64{
+		"1..5":                           {1, 2, 3, 4, 5},
+		"0 until 5":                      {0, 1, 2, 3, 4},
+		"0..<5":                          {0, 1, 2, 3, 4},
+		"0 until 10 step 2":              {0, 2, 4, 6, 8},
+		"10 downTo 1 step 3":             {10, 7, 4, 1},
+		"10 downTo 1 step 4":             {10, 6, 2},
+		"1..10 step 4":                   {1, 5, 9},
+		"5 downTo 3":                     {5, 4, 3},
+		"(1..5).reversed()":              {5, 4, 3, 2, 1},
+		"(1..10 step 4).reversed()":      {9, 5, 1},
+		"(0 until 10).reversed()":        {9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+		"(1..10).reversed() step 3":      {10, 7, 4, 1},
+		"(10 downTo 1).reversed()":       {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		"((1..3).reversed()).reversed()": {1, 2, 3},
+		"0..1 step 0.25":                 {0, 0.25, 0.5, 0.75, 1},
+		"5..1":                           nil,
+		"1 downTo 5":                     nil,
+		"0 until 0":                      nil,
+		"(5..1).reversed()":              nil,
+	} {
+		r, err := NewDialect2(exp, DialectKotlin)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, r.All(), expected)
+	}
+
+	r := NewDialect("0 until 10 step 2", DialectKotlin)
+	assert.Equal(t, r.String(), "[0, 10), float range with step 2")
+	assert.Equal(t, r.Valid(8), true)
+	assert.Equal(t, r.Valid(9), false)
+	assert.Equal(t, r.Valid(10), false)
+
+	r = NewDialect("10 downTo 1 step 3", DialectKotlin)
+	assert.Equal(t, r.String(), "[10, 1], float range with step -3")
+	assert.Equal(t, r.Valid(7), true)
+	assert.Equal(t, r.Valid(8), false)
+
+	for _, exp := range []string{"1..10 step 0", "1..10 step -2", "1 to 10", "1..x", "(1..10).reversed() step"} {
+		_, err := NewDialect2(exp, DialectKotlin)
+		assert.NotEqual(t, err, nil)
+	}
+}
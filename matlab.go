@@ -0,0 +1,275 @@
+package rangetype
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// newColonRange evaluates a MATLAB, Julia or R style range, like "1:10" or "1:2:10",
+// where the step is in the middle and the stop value is included if it is a whole number of steps away.
+// If countDown is true, "10:1" counts down, like in R. Otherwise it is empty, like in MATLAB and Julia.
+func newColonRange(rangeExpression string, countDown bool) (*Range, error) {
+	elements := strings.Split(rangeExpression, ":")
+	if len(elements) < 2 || len(elements) > 3 || (countDown && len(elements) == 3) {
+		return nil, ErrRangeSyntax
+	}
+	var values []float64
+	for _, element := range elements {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			return nil, ErrMissingRange
+		}
+		x, err := eval(element, false)
+		if err != nil {
+			return nil, errors.New("INVALID RANGE VALUE: " + element + ", " + err.Error())
+		}
+		values = append(values, x)
+	}
+	from, to, step := values[0], values[len(values)-1], 1.0
+	if len(values) == 3 {
+		step = values[1]
+	}
+	if countDown && from > to {
+		step = -step
+	}
+	if step == 0 {
+		return nil, errors.New("STEP SIZE CAN NOT BE ZERO: " + rangeExpression)
+	}
+	return newSequence(from, to, step), nil
+}
+
+// newSequence returns the numbers from the start value that are a whole number of steps away from it,
+// up to and including the stop value. If the stop value is before the start value, the range is empty.
+func newSequence(from, to, step float64) *Range {
+	steps := math.Floor((to-from)/step + stepTolerance)
+	if steps < 0 {
+		return empty(from)
+	}
+	return &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: from, to: from + steps*step, step: step}
+}
+
+// newLengthSequence returns n numbers from the start value, with the given step
+func newLengthSequence(from, step, n float64) (*Range, error) {
+	if n < 0 || math.Trunc(n) != n {
+		return nil, errors.New("INVALID LENGTH: " + strconv.FormatFloat(n, 'g', -1, 64))
+	}
+	if n == 0 {
+		return empty(from), nil
+	}
+	if step == 0 {
+		step = 1
+	}
+	return &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: from, to: from + (n-1)*step, step: step}, nil
+}
+
+// newMatlabRange evaluates a MATLAB range, like "1:2:10" or "linspace(0, 1, 11)"
+func newMatlabRange(rangeExpression string) (*Range, error) {
+	positional, named, ok := functionArguments(rangeExpression, "linspace")
+	if !ok {
+		return newColonRange(rangeExpression, false)
+	}
+	if len(named) > 0 || len(positional) < 2 || len(positional) > 3 {
+		return nil, errors.New("linspace NEEDS 2 OR 3 ARGUMENTS: " + rangeExpression)
+	}
+	values, err := evalArguments(positional)
+	if err != nil {
+		return nil, err
+	}
+	// linspace gives 100 numbers by default
+	from, to, n := values[0], values[1], 100.0
+	if len(values) == 3 {
+		n = values[2]
+	}
+	if n == 1 {
+		return newLengthSequence(to, 1, n)
+	}
+	return newLengthSequence(from, (to-from)/(n-1), n)
+}
+
+// newJuliaRange evaluates a Julia range, like "1:2:10", "range(1, 10, step=2)" or "range(0, 1; length=11)"
+func newJuliaRange(rangeExpression string) (*Range, error) {
+	positional, named, ok := functionArguments(rangeExpression, "range")
+	if !ok {
+		return newColonRange(rangeExpression, false)
+	}
+	if len(positional) > 3 {
+		return nil, errors.New("TOO MANY ARGUMENTS: " + rangeExpression)
+	}
+	// The positional arguments are start, stop and length
+	for i, name := range []string{"start", "stop", "length"}[:len(positional)] {
+		if _, ok := named[name]; ok {
+			return nil, errors.New("DUPLICATE ARGUMENT: " + name)
+		}
+		named[name] = positional[i]
+	}
+	values := make(map[string]float64)
+	for name, value := range named {
+		switch name {
+		case "start", "stop", "step", "length":
+		default:
+			return nil, errors.New("UNKNOWN ARGUMENT: " + name)
+		}
+		x, err := eval(value, false)
+		if err != nil {
+			return nil, errors.New("INVALID VALUE FOR " + name + ": " + value + ", " + err.Error())
+		}
+		values[name] = x
+	}
+	start, hasStart := values["start"]
+	stop, hasStop := values["stop"]
+	step, hasStep := values["step"]
+	length, hasLength := values["length"]
+	switch {
+	case hasStart && hasStop && hasLength && !hasStep:
+		if length == 1 {
+			return newLengthSequence(start, 1, length)
+		}
+		return newLengthSequence(start, (stop-start)/(length-1), length)
+	case hasLength && !(hasStart && hasStop):
+		if !hasStep {
+			step = 1
+		}
+		if !hasStart {
+			if !hasStop {
+				return nil, errors.New("MISSING START OR STOP: " + rangeExpression)
+			}
+			start = stop - (length-1)*step
+		}
+		return newLengthSequence(start, step, length)
+	case hasStart && hasStop && !hasLength:
+		if !hasStep {
+			step = 1
+		}
+		if step == 0 {
+			return nil, errors.New("STEP SIZE CAN NOT BE ZERO: " + rangeExpression)
+		}
+		return newSequence(start, stop, step), nil
+	}
+	return nil, errors.New("INVALID COMBINATION OF ARGUMENTS: " + rangeExpression)
+}
+
+// newRRange evaluates an R range, like "1:10", "10:1", "seq(1, 10, by=2)", "seq(0, 1, length.out=11)" or "seq_len(5)"
+func newRRange(rangeExpression string) (*Range, error) {
+	if positional, named, ok := functionArguments(rangeExpression, "seq_len"); ok {
+		if len(positional) != 1 || len(named) > 0 {
+			return nil, errors.New("seq_len NEEDS 1 ARGUMENT: " + rangeExpression)
+		}
+		values, err := evalArguments(positional)
+		if err != nil {
+			return nil, err
+		}
+		return newLengthSequence(1, 1, values[0])
+	}
+	positional, named, ok := functionArguments(rangeExpression, "seq")
+	if !ok {
+		return newColonRange(rangeExpression, true)
+	}
+	if len(positional) > 4 {
+		return nil, errors.New("TOO MANY ARGUMENTS: " + rangeExpression)
+	}
+	// The positional arguments are from, to, by and length.out, and "length" is short for "length.out"
+	if value, ok := named["length"]; ok {
+		delete(named, "length")
+		named["length.out"] = value
+	}
+	for i, name := range []string{"from", "to", "by", "length.out"}[:len(positional)] {
+		if _, ok := named[name]; ok {
+			return nil, errors.New("DUPLICATE ARGUMENT: " + name)
+		}
+		named[name] = positional[i]
+	}
+	values := make(map[string]float64)
+	for name, value := range named {
+		switch name {
+		case "from", "to", "by", "length.out":
+		default:
+			return nil, errors.New("UNKNOWN ARGUMENT: " + name)
+		}
+		x, err := eval(value, false)
+		if err != nil {
+			return nil, errors.New("INVALID VALUE FOR " + name + ": " + value + ", " + err.Error())
+		}
+		values[name] = x
+	}
+	from, hasFrom := values["from"]
+	to, hasTo := values["to"]
+	by, hasBy := values["by"]
+	length, hasLength := values["length.out"]
+	if !hasFrom {
+		from = 1
+	}
+	switch {
+	case hasLength && hasBy:
+		if hasFrom && hasTo {
+			return nil, errors.New("TOO MANY ARGUMENTS: " + rangeExpression)
+		}
+		if !hasFrom && hasTo {
+			from = to - (length-1)*by
+		}
+		return newLengthSequence(from, by, length)
+	case hasLength:
+		if !hasTo {
+			return newLengthSequence(from, 1, length)
+		}
+		if length == 1 {
+			return newLengthSequence(from, 1, length)
+		}
+		return newLengthSequence(from, (to-from)/(length-1), length)
+	case !hasTo:
+		if hasFrom && !hasBy && len(named) == 1 {
+			// seq(n) is the same as 1:n
+			return newColonRange("1:"+named["from"], true)
+		}
+		return nil, errors.New("MISSING ARGUMENT to: " + rangeExpression)
+	case !hasBy:
+		by = 1
+		if from > to {
+			by = -1
+		}
+	case by == 0 && from != to:
+		return nil, errors.New("STEP SIZE CAN NOT BE ZERO: " + rangeExpression)
+	case (to-from)*by < 0:
+		return nil, errors.New("WRONG SIGN IN by ARGUMENT: " + rangeExpression)
+	}
+	if by == 0 {
+		return newLengthSequence(from, 1, 1)
+	}
+	return newSequence(from, to, by), nil
+}
+
+// functionArguments returns the positional and named arguments, if the given string is a call to the given function,
+// like "seq(1, 10, by=2)". Arguments can be separated by "," or ";".
+func functionArguments(s, function string) (positional []string, named map[string]string, ok bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, function+"(") || !strings.HasSuffix(s, ")") {
+		return nil, nil, false
+	}
+	named = make(map[string]string)
+	s = s[len(function)+1 : len(s)-1]
+	if strings.TrimSpace(s) == "" {
+		return nil, named, true
+	}
+	for _, argument := range strings.FieldsFunc(s, func(c rune) bool { return c == ',' || c == ';' }) {
+		if pos := strings.Index(argument, "="); pos != -1 {
+			named[strings.TrimSpace(argument[:pos])] = strings.TrimSpace(argument[pos+1:])
+		} else {
+			positional = append(positional, strings.TrimSpace(argument))
+		}
+	}
+	return positional, named, true
+}
+
+// evalArguments evaluates the given function arguments
+func evalArguments(arguments []string) ([]float64, error) {
+	values := make([]float64, len(arguments))
+	for i, argument := range arguments {
+		x, err := eval(argument, false)
+		if err != nil {
+			return nil, errors.New("INVALID ARGUMENT: " + argument + ", " + err.Error())
+		}
+		values[i] = x
+	}
+	return values, nil
+}
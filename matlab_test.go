@@ -0,0 +1,87 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestMatlab(t *testing.T) {
+	for exp, expected := range map[string][]float64{
+		"1:5":               {1, 2, 3, 4, 5},
+		"1:2:10":            {1, 3, 5, 7, 9},
+		"10:-3:1":           {10, 7, 4, 1},
+		"0:0.25:1":          {0, 0.25, 0.5, 0.75, 1},
+		"5:1":               nil,
+		"1:-1:5":            nil,
+		"1 : 2**2":          {1, 2, 3, 4},
+		"linspace(0, 1, 5)": {0, 0.25, 0.5, 0.75, 1},
+		"linspace(1, 3, 1)": {3},
+	} {
+		r, err := NewDialect2(exp, DialectMatlab)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, r.All(), expected)
+	}
+
+	// Not Python's start:stop:step
+	r := NewDialect("1:2:10", DialectMatlab)
+	assert.Equal(t, r.Valid(9), true)
+	assert.Equal(t, r.Valid(2), false)
+	assert.Equal(t, r.Valid(10), false)
+	assert.Equal(t, len(NewDialect("linspace(0, 1)", DialectMatlab).All()), 100)
+
+	for _, exp := range []string{"1:0:5", "1:", ":5", "1:2:3:4", "linspace(1)", "1..5"} {
+		_, err := NewDialect2(exp, DialectMatlab)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestJulia(t *testing.T) {
+	for exp, expected := range map[string][]float64{
+		"1:2:10":                           {1, 3, 5, 7, 9},
+		"range(1, 10, step=3)":             {1, 4, 7, 10},
+		"range(1, 10; step=4)":             {1, 5, 9},
+		"range(0, 1, length=5)":            {0, 0.25, 0.5, 0.75, 1},
+		"range(0, 1, 3)":                   {0, 0.5, 1},
+		"range(1, 3)":                      {1, 2, 3},
+		"range(start=2, length=3)":         {2, 3, 4},
+		"range(stop=10, step=2, length=3)": {6, 8, 10},
+		"range(1; step=-1, length=3)":      {1, 0, -1},
+		"range(1, 0, length=0)":            nil,
+	} {
+		r, err := NewDialect2(exp, DialectJulia)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, r.All(), expected)
+	}
+	for _, exp := range []string{"1:0:5", "range(1)", "range(1, 10, step=0)", "range(1, 10, step=1, length=3)", "range(1, 10, by=2)", "range(1, 10, length=2.5)"} {
+		_, err := NewDialect2(exp, DialectJulia)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestR(t *testing.T) {
+	for exp, expected := range map[string][]float64{
+		"1:5":                        {1, 2, 3, 4, 5},
+		"5:1":                        {5, 4, 3, 2, 1},
+		"1.5:4":                      {1.5, 2.5, 3.5},
+		"seq(1, 10, by=2)":           {1, 3, 5, 7, 9},
+		"seq(10, 1, by=-3)":          {10, 7, 4, 1},
+		"seq(1, 10, 4)":              {1, 5, 9},
+		"seq(5, 1)":                  {5, 4, 3, 2, 1},
+		"seq(4)":                     {1, 2, 3, 4},
+		"seq(0, 1, length.out=3)":    {0, 0.5, 1},
+		"seq(2, by=2, length=3)":     {2, 4, 6},
+		"seq(to=10, by=2, length=3)": {6, 8, 10},
+		"seq(3, 3, by=0)":            {3},
+		"seq_len(3)":                 {1, 2, 3},
+		"seq_len(0)":                 nil,
+	} {
+		r, err := NewDialect2(exp, DialectR)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, r.All(), expected)
+	}
+	for _, exp := range []string{"1:2:10", "seq(1, 10, by=-1)", "seq(1, 10, by=0)", "seq_len(-1)", "seq_len(1, 2)", "seq(1, 10, step=2)", "seq(by=2)"} {
+		_, err := NewDialect2(exp, DialectR)
+		assert.NotEqual(t, err, nil)
+	}
+}
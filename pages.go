@@ -0,0 +1,149 @@
+package rangetype
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PageList is a sorted list of integer ranges without overlaps, for selecting pages or lines,
+// like "1-3,5,7-9,20-" in print dialogs. The last range may be open ended, like "20-".
+type PageList []*Range
+
+// ParsePageList parses a comma separated list of page or line numbers, like "1-3,5,7-9,20-".
+// Each element is a number, a range like "7-9", a range without an end like "20-" or a range without a start like "-3".
+// The ranges are sorted, and ranges that overlap or are adjacent are merged.
+func ParsePageList(s string) (PageList, error) {
+	type span struct{ first, last float64 }
+	var spans []span
+	for _, element := range strings.Split(s, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		left, right, found := strings.Cut(element, "-")
+		left, right = strings.TrimSpace(left), strings.TrimSpace(right)
+		if found && left == "" && right == "" {
+			return nil, errors.New("INVALID PAGE RANGE: " + element)
+		}
+		sp := span{1, math.Inf(1)}
+		if left != "" {
+			first, err := strconv.ParseUint(left, 10, 32)
+			if err != nil {
+				return nil, errors.New("INVALID PAGE NUMBER: " + left)
+			}
+			sp.first = float64(first)
+		}
+		if !found {
+			sp.last = sp.first
+		} else if right != "" {
+			last, err := strconv.ParseUint(right, 10, 32)
+			if err != nil {
+				return nil, errors.New("INVALID PAGE NUMBER: " + right)
+			}
+			sp.last = float64(last)
+		}
+		if sp.first > sp.last {
+			return nil, errors.New("INVALID PAGE RANGE: " + element)
+		}
+		spans = append(spans, sp)
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].first < spans[j].first })
+	var pages PageList
+	for _, sp := range spans {
+		if len(pages) > 0 {
+			current := pages[len(pages)-1]
+			if sp.first <= current.to+1 {
+				if sp.last > current.to {
+					current.to = sp.last
+					if math.IsInf(sp.last, 1) {
+						current.rangeType = RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP
+					}
+				}
+				continue
+			}
+		}
+		r := &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: sp.first, to: sp.last, step: 1}
+		if math.IsInf(sp.last, 1) {
+			r.rangeType = RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP
+		}
+		pages = append(pages, r)
+	}
+	return pages, nil
+}
+
+// Has checks if the given page or line number is in the list
+func (pages PageList) Has(n int) bool {
+	for _, r := range pages {
+		if r.ValidInt(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// last returns the last page or line number in the list, which may be +Inf
+func (pages PageList) last() float64 {
+	if len(pages) == 0 {
+		return 0
+	}
+	return pages[len(pages)-1].to
+}
+
+// String returns the list in a compact form, like "1-3,5,7-9,20-"
+func (pages PageList) String() string {
+	var sb strings.Builder
+	for i, r := range pages {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(strconv.Itoa(int(r.from)))
+		switch {
+		case math.IsInf(r.to, 1):
+			sb.WriteString("-")
+		case r.to != r.from:
+			sb.WriteString("-")
+			sb.WriteString(strconv.Itoa(int(r.to)))
+		}
+	}
+	return sb.String()
+}
+
+// ForEachLine calls the given function for each line that is read from r, if the line number is in the list.
+// Line numbers start at 1, and the lines include the line ending, if there is one.
+// Reading stops after the last line in the list.
+func (pages PageList) ForEachLine(r io.Reader, f func(n int, line string)) error {
+	last := pages.last()
+	br := bufio.NewReader(r)
+	for n := 1; float64(n) <= last; n++ {
+		line, err := br.ReadString('\n')
+		if line != "" && pages.Has(n) {
+			f(n, line)
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilterLines copies the lines from r to w, if the line number is in the list
+func (pages PageList) FilterLines(w io.Writer, r io.Reader) error {
+	var werr error
+	err := pages.ForEachLine(r, func(_ int, line string) {
+		if werr == nil {
+			_, werr = io.WriteString(w, line)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return werr
+}
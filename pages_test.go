@@ -0,0 +1,68 @@
+package rangetype
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestPageList(t *testing.T) {
+	for s, expected := range map[string]string{
+		"1-3,5,7-9,20-": "1-3,5,7-9,20-",
+		"5, 1-3":        "1-3,5",
+		"1-3,4,6":       "1-4,6",
+		"1-5,2-3":       "1-5",
+		"7-9,8-12,3":    "3,7-12",
+		"-3,10":         "1-3,10",
+		"20-,5,25-30":   "5,20-",
+		"4-4":           "4",
+		" 2 - 4 , , 9 ": "2-4,9",
+		"":              "",
+		"1-,3":          "1-",
+	} {
+		pages, err := ParsePageList(s)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, pages.String(), expected)
+	}
+
+	pages, err := ParsePageList("1-3,5,7-9,20-")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(pages), 4)
+	assert.Equal(t, pages[0].All(), []float64{1, 2, 3})
+	for n, expected := range map[int]bool{0: false, 1: true, 3: true, 4: false, 5: true, 6: false, 9: true, 19: false, 20: true, 1000000: true} {
+		assert.Equal(t, pages.Has(n), expected)
+	}
+
+	for _, s := range []string{"a", "1-b", "5-3", "-", "1-2-3", "3-1"} {
+		_, err := ParsePageList(s)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestFilterLines(t *testing.T) {
+	text := "one\ntwo\nthree\nfour\nfive\nsix"
+	pages, err := ParsePageList("2-3,5-")
+	assert.Equal(t, err, nil)
+	var buf bytes.Buffer
+	assert.Equal(t, pages.FilterLines(&buf, strings.NewReader(text)), nil)
+	assert.Equal(t, buf.String(), "two\nthree\nfive\nsix")
+
+	var numbers []int
+	pages, err = ParsePageList("1,3,100")
+	assert.Equal(t, err, nil)
+	err = pages.ForEachLine(strings.NewReader(text), func(n int, line string) {
+		numbers = append(numbers, n)
+	})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, numbers, []int{1, 3})
+
+	// Reading stops after the last line in the list
+	r := strings.NewReader(text)
+	pages, err = ParsePageList("1")
+	assert.Equal(t, err, nil)
+	buf.Reset()
+	assert.Equal(t, pages.FilterLines(&buf, r), nil)
+	assert.Equal(t, buf.String(), "one\n")
+}
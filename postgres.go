@@ -0,0 +1,219 @@
+package rangetype
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrPGRangeBounds = errors.New("RANGE LOWER BOUND MUST BE LESS THAN OR EQUAL TO RANGE UPPER BOUND")
+
+// PGRange is a PostgreSQL range, like int4range, int8range or numrange.
+// It can be scanned from and written to a database with database/sql.
+//
+// Ranges are written and read as PostgreSQL range literals, like "[1,10)", "(,5]" or "empty".
+// A missing bound means that the range has no start or stop value.
+//
+// If Discrete is true, the ranges are integer ranges that are normalized to the "[a,b)" form,
+// like for int4range and int8range. Otherwise, the ranges are continuous, like for numrange.
+//
+// The Range is nil for NULL.
+type PGRange struct {
+	*Range
+	Discrete bool
+}
+
+// ParsePGRange parses a PostgreSQL range literal, like "[1,10)", "(,5]" or "empty".
+// If discrete is true, the bounds must be integers and the range is normalized to the "[a,b)" form.
+func ParsePGRange(literal string, discrete bool) (*PGRange, error) {
+	p := &PGRange{Discrete: discrete}
+	if err := p.parse(literal); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parse parses a PostgreSQL range literal and sets the Range
+func (p *PGRange) parse(literal string) error {
+	s := strings.TrimSpace(literal)
+	if strings.EqualFold(s, "empty") {
+		p.Range = empty(0)
+		return nil
+	}
+	if len(s) < 3 || !strings.ContainsAny(s[:1], "[(") || !strings.ContainsAny(s[len(s)-1:], "])") {
+		return errors.New("MALFORMED RANGE LITERAL: " + literal)
+	}
+	lower, upper, err := pgBounds(s[1 : len(s)-1])
+	if err != nil {
+		return errors.New("MALFORMED RANGE LITERAL: " + literal + ", " + err.Error())
+	}
+
+	r := &Range{rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: math.Inf(-1), to: math.Inf(1), step: 0}
+	if p.Discrete {
+		r.step = 1
+	}
+	if s[0] == '(' || lower == "" {
+		r.rangeType = (r.rangeType & ^RANGE_INCLUDE_START) | RANGE_EXCLUDE_START
+	}
+	if s[len(s)-1] == ')' || upper == "" {
+		r.rangeType = (r.rangeType & ^RANGE_INCLUDE_STOP) | RANGE_EXCLUDE_STOP
+	}
+	if lower != "" {
+		if r.from, err = p.parseBound(lower); err != nil {
+			return err
+		}
+	}
+	if upper != "" {
+		if r.to, err = p.parseBound(upper); err != nil {
+			return err
+		}
+	}
+	if r.from > r.to {
+		return ErrPGRangeBounds
+	}
+	if p.Discrete {
+		r = pgCanonical(r)
+	}
+	if r.IsEmpty() {
+		r = empty(0)
+	}
+	p.Range = r
+	return nil
+}
+
+// parseBound parses a bound of a range literal, which must be an integer for discrete ranges
+func (p *PGRange) parseBound(bound string) (float64, error) {
+	if p.Discrete {
+		i, err := strconv.ParseInt(bound, 10, 64)
+		if err != nil {
+			return 0, errors.New("INVALID INPUT SYNTAX FOR INTEGER: " + bound)
+		}
+		return float64(i), nil
+	}
+	x, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return 0, errors.New("INVALID INPUT SYNTAX FOR NUMERIC: " + bound)
+	}
+	return x, nil
+}
+
+// pgBounds splits the contents of a range literal, like "1,10" or "\"1\",", into the lower and upper bound.
+// Bounds may be quoted, and characters may be escaped with "\".
+func pgBounds(s string) (string, string, error) {
+	var (
+		bounds  []string
+		current strings.Builder
+		quoted  bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			current.WriteByte(s[i])
+		case c == '"':
+			if quoted && i+1 < len(s) && s[i+1] == '"' {
+				// A doubled quote within quotes
+				i++
+				current.WriteByte('"')
+			} else {
+				quoted = !quoted
+			}
+		case c == ',' && !quoted:
+			bounds = append(bounds, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if quoted {
+		return "", "", errors.New("UNTERMINATED QUOTE")
+	}
+	bounds = append(bounds, strings.TrimSpace(current.String()))
+	if len(bounds) != 2 {
+		return "", "", errors.New("TOO MANY COMMAS")
+	}
+	return bounds[0], bounds[1], nil
+}
+
+// pgCanonical returns a discrete range in the canonical "[a,b)" form, like PostgreSQL does for int4range
+func pgCanonical(r *Range) *Range {
+	if r.IsEmpty() {
+		return empty(0)
+	}
+	canonical := &Range{rangeType: RANGE_INCLUDE_START | RANGE_EXCLUDE_STOP, from: r.from, to: r.to, step: 1}
+	if math.IsInf(r.from, 0) {
+		canonical.rangeType = RANGE_EXCLUDE_START | RANGE_EXCLUDE_STOP
+	} else if (r.rangeType & RANGE_EXCLUDE_START) != 0 {
+		canonical.from++
+	}
+	if !math.IsInf(r.to, 0) && (r.rangeType&RANGE_EXCLUDE_STOP) == 0 {
+		canonical.to++
+	}
+	if canonical.from >= canonical.to {
+		return empty(0)
+	}
+	return canonical
+}
+
+// String returns the range as a PostgreSQL range literal, like "[1,10)", "(,5]" or "empty"
+func (p PGRange) String() string {
+	if p.Range == nil {
+		return ""
+	}
+	r := p.Range
+	if p.Discrete {
+		r = pgCanonical(r)
+	}
+	if r.IsEmpty() {
+		return "empty"
+	}
+	var sb strings.Builder
+	if math.IsInf(r.from, 0) {
+		sb.WriteString("(")
+	} else {
+		if (r.rangeType & RANGE_EXCLUDE_START) != 0 {
+			sb.WriteString("(")
+		} else {
+			sb.WriteString("[")
+		}
+		sb.WriteString(strconv.FormatFloat(r.from, 'f', -1, 64))
+	}
+	sb.WriteString(",")
+	if math.IsInf(r.to, 0) {
+		sb.WriteString(")")
+	} else {
+		sb.WriteString(strconv.FormatFloat(r.to, 'f', -1, 64))
+		if (r.rangeType & RANGE_EXCLUDE_STOP) != 0 {
+			sb.WriteString(")")
+		} else {
+			sb.WriteString("]")
+		}
+	}
+	return sb.String()
+}
+
+// Scan implements the sql.Scanner interface. NULL sets the Range to nil.
+func (p *PGRange) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		p.Range = nil
+		return nil
+	case string:
+		return p.parse(v)
+	case []byte:
+		return p.parse(string(v))
+	}
+	return fmt.Errorf("CAN NOT SCAN RANGE FROM %T", src)
+}
+
+// Value implements the driver.Valuer interface. A nil Range is written as NULL.
+func (p PGRange) Value() (driver.Value, error) {
+	if p.Range == nil {
+		return nil, nil
+	}
+	return p.String(), nil
+}
@@ -0,0 +1,126 @@
+package rangetype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+// Make sure that PGRange can be used with database/sql
+var (
+	_ sql.Scanner   = &PGRange{}
+	_ driver.Valuer = PGRange{}
+)
+
+func TestPGRangeDiscrete(t *testing.T) {
+	for literal, expected := range map[string]string{
+		"[1,10)":        "[1,10)",
+		"[1,10]":        "[1,11)",
+		"(1,10]":        "[2,11)",
+		"(1,10)":        "[2,10)",
+		" [ 1 , 10 ] ":  "[1,11)",
+		"[\"1\",\"3\"]": "[1,4)",
+		"(,5]":          "(,6)",
+		"[5,)":          "[5,)",
+		"(,)":           "(,)",
+		"[,]":           "(,)",
+		"empty":         "empty",
+		"EMPTY":         "empty",
+		"[3,3)":         "empty",
+		"(3,3]":         "empty",
+		"(3,4)":         "empty",
+		"[3,3]":         "[3,4)",
+		"[-5,-2]":       "[-5,-1)",
+	} {
+		p, err := ParsePGRange(literal, true)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, p.String(), expected)
+	}
+
+	p, err := ParsePGRange("(1,5]", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, p.All(), []float64{2, 3, 4, 5})
+	assert.Equal(t, p.Valid(1), false)
+	assert.Equal(t, p.Valid(5), true)
+	assert.Equal(t, p.Valid(6), false)
+
+	p, err = ParsePGRange("(,0)", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, p.Valid(-1000000), true)
+	assert.Equal(t, p.Valid(0), false)
+
+	p, err = ParsePGRange("empty", true)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, p.IsEmpty(), true)
+	assert.Equal(t, len(p.All()), 0)
+
+	for _, literal := range []string{"", "1,10", "[1,10", "[1;10]", "[1,2,3]", "[a,10]", "[1.5,10]", "[10,1]", "[\"1,10]"} {
+		_, err := ParsePGRange(literal, true)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestPGRangeContinuous(t *testing.T) {
+	for literal, expected := range map[string]string{
+		"[1.5,10)":   "[1.5,10)",
+		"(0.1,0.2]":  "(0.1,0.2]",
+		"[1,10]":     "[1,10]",
+		"(,2.5]":     "(,2.5]",
+		"[-1.25,)":   "[-1.25,)",
+		"[3,3]":      "[3,3]",
+		"[3,3)":      "empty",
+		"[1e3,2e3]":  "[1000,2000]",
+		"( 0 , 1 )":  "(0,1)",
+		"empty":      "empty",
+		"[\"-1\",1)": "[-1,1)",
+	} {
+		p, err := ParsePGRange(literal, false)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, p.String(), expected)
+	}
+
+	p, err := ParsePGRange("(0,1]", false)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, p.Valid(0), false)
+	assert.Equal(t, p.Valid(0.5), true)
+	assert.Equal(t, p.Valid(1), true)
+	assert.Equal(t, p.Valid(1.5), false)
+
+	_, err = ParsePGRange("[2.5,1)", false)
+	assert.Equal(t, err, ErrPGRangeBounds)
+}
+
+func TestPGRangeSQL(t *testing.T) {
+	var p PGRange
+	p.Discrete = true
+	assert.Equal(t, p.Scan("[1,10]"), nil)
+	assert.Equal(t, p.String(), "[1,11)")
+	v, err := p.Value()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, driver.Value("[1,11)"))
+
+	assert.Equal(t, p.Scan([]byte("(,5]")), nil)
+	assert.Equal(t, p.String(), "(,6)")
+
+	assert.Equal(t, p.Scan(nil), nil)
+	assert.Equal(t, p.Range == nil, true)
+	v, err = p.Value()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, nil)
+
+	assert.NotEqual(t, p.Scan(42), nil)
+	assert.NotEqual(t, p.Scan("[5,1]"), nil)
+
+	// Ranges created from other expressions are written as range literals too
+	v, err = PGRange{Range: New("1..10")}.Value()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, driver.Value("[1,10]"))
+	v, err = PGRange{Range: New("1..10"), Discrete: true}.Value()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, driver.Value("[1,11)"))
+	v, err = PGRange{Range: New("[0,1)")}.Value()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, v, driver.Value("[0,1)"))
+}
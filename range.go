@@ -43,6 +43,9 @@ const (
 	RANGE_EXCLUDE_STOP
 	RANGE_INCLUDE_STOP
 
+	// How close a number must be to a step in the range, relative to the step size, to be counted as equal
+	stepTolerance = 1e-9
+
 	// Thanks https://groups.google.com/forum/#!msg/golang-nuts/a9PitPAHSSU/ziQw1-QHw3EJ
 	MaxUint = ^uint(0)
 	MinUint = 0
@@ -53,6 +56,7 @@ const (
 var (
 	ErrRangeSyntax  = errors.New("INVALID RANGE SYNTAX")
 	ErrMissingRange = errors.New("MISSING RANGE VALUES")
+	ErrUnbounded    = errors.New("RANGE IS UNBOUNDED")
 )
 
 // Range can represent a number type in a programming language
@@ -64,6 +68,9 @@ type Range struct {
 	from      float64
 	to        float64
 	step      float64
+	precision int  // significant decimal digits, for floating point types like Ada's "digits 6"
+	width     int  // zero padded width when joining, like for "{001..100}" in Bash
+	runes     bool // join the numbers as characters, like for "{a..e}" in Bash
 }
 
 // Valid is an alias for ValidFloat
@@ -77,8 +84,12 @@ func (r *Range) ValidInt(i int) bool {
 }
 
 // ValidFloat checks if the given float is in the range,
-// using half the range step size as the threshold for float equality
+// using half the range step size as the threshold for float equality.
+// If the range has a precision, x must also fit within that many significant digits.
 func (r *Range) ValidFloat(x float64) bool {
+	if r.precision > 0 && !fitsDigits(x, r.precision) {
+		return false
+	}
 	halfStep := r.step / 2.0
 	retval := r.Has(x, halfStep)
 	//yn := map[bool]string{true: "YES", false: "NO"}
@@ -86,6 +97,26 @@ func (r *Range) ValidFloat(x float64) bool {
 	return retval
 }
 
+// validStep checks if the given float is in the range, like ValidFloat,
+// but x must be within a tiny fraction of the step size from one of the steps in the range.
+func (r *Range) validStep(x float64) bool {
+	return r.validWithin(x, abs(r.step)*stepTolerance)
+}
+
+// validWithin checks if the given float is in the range, like validStep, but with the given threshold for float equality
+func (r *Range) validWithin(x, threshold float64) bool {
+	if r.precision > 0 && !fitsDigits(x, r.precision) {
+		return false
+	}
+	return r.hasStep(x, threshold)
+}
+
+// fitsDigits checks if the given float can be written with the given number of significant digits
+func fitsDigits(x float64, digits int) bool {
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(x, 'g', digits, 64), 64)
+	return err == nil && rounded == x
+}
+
 // Has checks if a given number is in the range.
 // If the difference between the given float and the float in the range are
 // less than the given threshold, they are counted as equal.
@@ -106,13 +137,13 @@ func (r *Range) Has(x, threshold float64) bool {
 	}
 	// If the range type is an integer (step size is 1 or -1), check if x is an integer
 	if r.Integer() {
-		if float64(int(x)) != x {
+		if math.Trunc(x) != x {
 			// Number differs when converting to an int and back to a float
 			return false
 		}
 		// If both to and from are integers too, just check if x is between those two, and
 		// then it is within range.
-		if float64(int(r.from)) == r.from && float64(int(r.to)) == r.to {
+		if math.Trunc(r.from) == r.from && math.Trunc(r.to) == r.to {
 			// Boundaries has already been checked, so just check between from and to
 			if x > a && a < b {
 				return true
@@ -120,6 +151,16 @@ func (r *Range) Has(x, threshold float64) bool {
 		}
 	}
 
+	// A range without a step size is continuous, like a floating point type
+	if r.step == 0 {
+		return true
+	}
+
+	// A range without a start or a stop value can not be iterated
+	if math.IsInf(r.from, 0) || math.IsInf(r.to, 0) {
+		return r.hasStep(x, threshold)
+	}
+
 	if r.step > 0 && r.step < 1 {
 		// If the step size is 0.1, extract the start value from x and check if it ends with 0.1
 		translated := (x - r.from)
@@ -141,9 +182,54 @@ func (r *Range) Has(x, threshold float64) bool {
 	return found
 }
 
-// almostEqual checks if the difference between two floats are under the given threshold
+// hasStep checks if a given number is in the range and a whole number of steps away from the start value,
+// without iterating. If the difference between x and the nearest step is less than the given threshold,
+// they are counted as equal.
+func (r *Range) hasStep(x, threshold float64) bool {
+	a := min(r.from, r.to)
+	b := max(r.from, r.to)
+	if x < a || x > b {
+		return false
+	}
+	if ((r.rangeType & RANGE_EXCLUDE_START) != 0) && almostEqual(x, r.from, threshold) {
+		return false
+	}
+	if ((r.rangeType & RANGE_EXCLUDE_STOP) != 0) && almostEqual(x, r.to, threshold) {
+		return false
+	}
+
+	// A range without a step size is continuous, like a floating point type
+	if r.step == 0 {
+		return true
+	}
+
+	// An inclusive stop value is always a part of the range, like in ForEach,
+	// even if it is not a whole number of steps away from the start value
+	if ((r.rangeType & RANGE_EXCLUDE_STOP) == 0) && almostEqual(x, r.to, threshold) {
+		return true
+	}
+
+	// Check if x is a whole number of steps away from the start value
+	anchor := r.anchor()
+	steps := (x - anchor) / r.step
+	return almostEqual(steps*r.step, math.Round(steps)*r.step, threshold)
+}
+
+// anchor returns the value that the steps in the range are counted from, which is the start value.
+// If there is no start value, like for "..=10" in Rust, the steps are counted from the stop value instead.
+func (r *Range) anchor() float64 {
+	if !math.IsInf(r.from, 0) {
+		return r.from
+	}
+	if !math.IsInf(r.to, 0) {
+		return r.to
+	}
+	return 0
+}
+
+// almostEqual checks if two floats are equal, or if the difference between them is under the given threshold
 func almostEqual(a, b, threshold float64) bool {
-	return abs(a-b) < threshold
+	return a == b || abs(a-b) < threshold
 }
 
 // Find searches a range for a given number
@@ -195,14 +281,16 @@ func rsplit(s, sep string) (string, string) {
 // > 10**2~
 // 99
 //
-// If "ada" is true, parenthesis are used to group expressions
-// instead of specifying exclusive ranges. The idea is to support range types like in Ada.
-//
+// If "ada" is true, the expression is evaluated as an Ada expression instead, see evalAda.
+// The idea is to support range types like in Ada.
 func eval(exp string, ada bool) (retval float64, err error) {
 	if strings.TrimSpace(exp) == "" {
 		// Return 0.0
 		return retval, nil
 	}
+	if ada {
+		return evalAda(exp)
+	}
 	if strings.HasPrefix(exp, "-") {
 		// Evaluate the expression with "-" removed
 		var v float64
@@ -212,55 +300,15 @@ func eval(exp string, ada bool) (retval float64, err error) {
 		// Return the result of the evaluated expression, but times -1
 		return -1 * v, nil
 	}
-	if ada {
-		if strings.TrimSpace(exp) == "-" {
-			return -1, nil
-		}
-		if strings.Count(exp, "(") != strings.Count(exp, ")") {
-			return retval, errors.New("Unbalanced expression: " + exp)
-		}
-		if strings.Contains(exp, "Integer'Last") {
-			exp = strings.Replace(exp, "Integer'Last", strconv.Itoa(MaxInt), -1) // 2**31-1 in Ada
-		}
-		//if strings.Contains(exp, "Integer'First") {
-		//	exp = strings.Replace(exp, "Integer'First", strconv.Itoa(-MinInt), -1) // Integer'First is 2**31 in Ada, but why?
-		//}
-		if strings.Count(exp, "(") > 0 && strings.Count(exp, ")") > 0 {
-			// There is a ( and a ), evaluate the expression in between and replace it
-			// with result of the evaluation.
-			elems := strings.SplitN(exp, "(", 2)
-			left := elems[0]
-			center, right := rsplit(elems[1], ")")
-			//fmt.Println("left center right", left, "|", center, "|", right)
-			centerResult, err := eval(center, ada)
-			if err != nil {
-				return retval, err
-			}
-			leftResult, err := eval(left, ada)
-			if err != nil {
-				return retval, err
-			}
-			rightResult, err := eval(right, ada)
-			if err != nil {
-				return retval, err
-			}
-			//fmt.Println("left result", leftResult)
-			//fmt.Println("center result", centerResult)
-			//fmt.Println("right result", rightResult)
-			//fmt.Println("total result:", leftResult + centerResult + rightResult)
-			return leftResult + centerResult + rightResult, nil
-		}
-	} else {
-		// Special syntax for ~ meaning -1
-		if strings.HasSuffix(exp, "~") {
-			// Evaluate the expression with "~" removed
-			var v float64
-			if v, err = eval(exp[:len(exp)-1], ada); err != nil {
-				return v, err
-			}
-			// Return the result of the evaluated expression, but subtract 1
-			return v - 1, nil
+	// Special syntax for ~ meaning -1
+	if strings.HasSuffix(exp, "~") {
+		// Evaluate the expression with "~" removed
+		var v float64
+		if v, err = eval(exp[:len(exp)-1], ada); err != nil {
+			return v, err
 		}
+		// Return the result of the evaluated expression, but subtract 1
+		return v - 1, nil
 	}
 	if strings.Count(exp, "**") > 0 {
 		elements := strings.SplitN(exp, "**", 2)
@@ -314,12 +362,108 @@ func New2(rangeExpression string) (*Range, error) {
 	return NewRange(rangeExpression, false)
 }
 
-// NewRange evaluates the given input string and returns a Range struct
+// NewRange evaluates the given input string and returns a Range struct.
+// Bash brace expansions, like "{1..10..2}" or "{001..100}", are also evaluated when ada is false.
 func NewRange(rangeExpression string, ada bool) (*Range, error) {
+	r, _, err := newRange(rangeExpression, ada)
+	return r, err
+}
+
+// newRange evaluates the given input string, like NewRange, and also returns if a step size is given.
+// Ada type declarations and Bash brace expansions always have a step size.
+func newRange(rangeExpression string, ada bool) (*Range, bool, error) {
+	if ada && adaType(rangeExpression) {
+		r, err := newAdaType(rangeExpression)
+		return r, true, err
+	}
+	if !ada && strings.HasPrefix(strings.TrimSpace(rangeExpression), "{") {
+		r, err := newBashRange(rangeExpression)
+		return r, true, err
+	}
+	r := &Range{step: 1.0}
+	rangeExpression, err := cutOptions(rangeExpression, r)
+	if err != nil {
+		return nil, false, err
+	}
+	parts, err := splitRange(rangeExpression, ada)
+	if err != nil {
+		return nil, false, err
+	}
+	r.rangeType = parts.rangeType
+	left, right, step := parts.left, parts.right, parts.step
+
+	// Left side of the range expression
+	if left == "" {
+		// If the left side is missing, use 0
+		r.from = 0.0
+	} else if r.from, err = eval(left, ada); err != nil {
+		return nil, false, errors.New("INVALID RANGE VALUE: " + step + ", " + err.Error())
+	}
+
+	// Right side of the range expression
+	if right == "" {
+		return nil, false, ErrMissingRange
+	} else if r.to, err = eval(right, ada); err != nil {
+		return nil, false, errors.New("INVALID RANGE VALUE: " + step + ", " + err.Error())
+	}
+
+	if step != "" {
+		if r.step, err = eval(step, ada); err != nil {
+			return nil, false, errors.New("INVALID STEP SIZE: " + step + ", " + err.Error())
+		}
+	}
+	return r, step != "", nil
+}
+
+// cutOptions removes the options that may come after the step size in a canonical range expression, like
+// " digits 6" for the precision, " width 3" for the zero padded width and " runes" for characters,
+// and sets them in the given range. The rest of the range expression is returned.
+func cutOptions(rangeExpression string, r *Range) (string, error) {
+	fields := strings.Fields(rangeExpression)
+	count := len(fields)
+	for len(fields) > 1 {
+		last := fields[len(fields)-1]
+		if last == "runes" {
+			r.runes = true
+			fields = fields[:len(fields)-1]
+			continue
+		}
+		option := fields[len(fields)-2]
+		if option != "digits" && option != "width" {
+			break
+		}
+		n, err := strconv.Atoi(last)
+		if err != nil || n < 0 || (option == "digits" && n == 0) {
+			return "", errors.New("INVALID " + strings.ToUpper(option) + ": " + last)
+		}
+		if option == "digits" {
+			r.precision = n
+		} else {
+			r.width = n
+		}
+		fields = fields[:len(fields)-2]
+	}
+	if len(fields) == count {
+		return rangeExpression, nil
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// rangeParts is a range expression that has been split into parts, but not yet evaluated
+type rangeParts struct {
+	rangeType int
+	left      string
+	right     string
+	step      string
+	separator string // "..", "," or ":"
+}
+
+// splitRange splits a range expression into the left side, the right side and the step size,
+// and sets the range type from the given brackets and separator
+func splitRange(rangeExpression string, ada bool) (*rangeParts, error) {
 	var (
-		r           = &Range{step: 1.0}
+		r           = &rangeParts{}
 		contents    string
-		err         error
 		left, right string
 		step        string
 	)
@@ -416,28 +560,43 @@ func NewRange(rangeExpression string, ada bool) (*Range, error) {
 	} else {
 		return nil, ErrRangeSyntax
 	}
+	r.left, r.right, r.step = left, right, step
+	r.separator = separatorOf(contents)
+	return r, nil
+}
 
-	// Left side of the range expression
-	if left == "" {
-		// If the left side is missing, use 0
-		r.from = 0.0
-	} else if r.from, err = eval(left, ada); err != nil {
-		return nil, errors.New("INVALID RANGE VALUE: " + step + ", " + err.Error())
+// separatorOf returns the separator that is used in the given range expression contents
+func separatorOf(contents string) string {
+	for _, sep := range []string{"..", ",", ":"} {
+		if strings.Contains(contents, sep) {
+			return sep
+		}
 	}
+	return ""
+}
 
-	// Right side of the range expression
-	if right == "" {
-		return nil, ErrMissingRange
-	} else if r.to, err = eval(right, ada); err != nil {
-		return nil, errors.New("INVALID RANGE VALUE: " + step + ", " + err.Error())
+// Digits returns the number of significant decimal digits in the range,
+// or 0 if the range is not limited by a precision
+func (r *Range) Digits() int {
+	return r.precision
+}
+
+// First returns the first number in the range
+func (r *Range) First() float64 {
+	if ((r.rangeType & RANGE_EXCLUDE_START) != 0) && !math.IsInf(r.from, 0) {
+		return r.from + r.step
 	}
+	return r.from
+}
 
-	if step != "" {
-		if r.step, err = eval(step, ada); err != nil {
-			return nil, errors.New("INVALID STEP SIZE: " + step + ", " + err.Error())
-		}
+// Last returns the last number in the range. This is the stop value if it is inclusive,
+// or the last step before the stop value if it is exclusive.
+func (r *Range) Last() float64 {
+	if ((r.rangeType & RANGE_EXCLUDE_STOP) == 0) || math.IsInf(r.to, 0) || r.step == 0 {
+		return r.to
 	}
-	return r, nil
+	steps := math.Ceil((r.to-r.from)/r.step-stepTolerance) - 1
+	return r.from + steps*r.step
 }
 
 // Integer checks if the range has a step of 1 or -1
@@ -447,6 +606,25 @@ func (r *Range) Integer() bool {
 
 // String returns the range as a string where "[" means inclusive and "(" means exclusive
 func (r *Range) String() string {
+	s := r.Interval()
+
+	// Why "integer" instead of "step 1"?
+	// The idea is to use a range to specify a number type in a future programming language.
+	// By specifying a range with a step, all ints/floats/uints/bytes can be clearly defined in one single unified way.
+	if r.precision > 0 {
+		s += fmt.Sprintf(", float range with %d digits", r.precision)
+	} else if r.Integer() {
+		s += ", integer range"
+	} else {
+		s += fmt.Sprintf(", float range with step %v", r.step)
+	}
+
+	return s
+}
+
+// Interval returns the start and stop values of the range, like "[1, 64]" or "[0, 1)",
+// with "[" and "]" for inclusive values and "(" and ")" for exclusive values
+func (r *Range) Interval() string {
 	s := ""
 
 	if (r.rangeType & RANGE_EXCLUDE_START) != 0 { // check if set
@@ -463,18 +641,25 @@ func (r *Range) String() string {
 		s += "]"
 	}
 
-	// Why "integer" instead of "step 1"?
-	// The idea is to use a range to specify a number type in a future programming language.
-	// By specifying a range with a step, all ints/floats/uints/bytes can be clearly defined in one single unified way.
-	if r.Integer() {
-		s += ", integer range"
-	} else {
-		s += fmt.Sprintf(", float range with step %v", r.step)
-	}
-
 	return s
 }
 
+// IsEmpty checks if there are no numbers in the range, like for "[5,5)"
+func (r *Range) IsEmpty() bool {
+	return r.from == r.to && ((r.rangeType&RANGE_EXCLUDE_START) != 0 || (r.rangeType&RANGE_EXCLUDE_STOP) != 0)
+}
+
+// unbounded checks if the range has no start or no stop value, like "5.." or "..=10" in Rust,
+// which are stored as infinite bounds
+func (r *Range) unbounded() bool {
+	return !r.IsEmpty() && (math.IsInf(r.from, 0) || math.IsInf(r.to, 0))
+}
+
+// empty returns a range without any numbers, at the given position
+func empty(at float64) *Range {
+	return &Range{rangeType: RANGE_EXCLUDE_START | RANGE_EXCLUDE_STOP, from: at, to: at, step: 1}
+}
+
 // abs returns the absolute number
 func abs(x float64) float64 {
 	if x < 0 {
@@ -499,27 +684,48 @@ func max(a, b float64) float64 {
 	return b
 }
 
+// includeStart checks if the start value should be included when iterating,
+// which it is not if the start value is also an excluded stop value
+func (r *Range) includeStart() bool {
+	if (r.rangeType & RANGE_INCLUDE_START) == 0 {
+		return false
+	}
+	return r.to != r.from || (r.rangeType&RANGE_EXCLUDE_STOP) == 0
+}
+
+// includeStop checks if the stop value should be included when iterating,
+// which it is not if it is also the start value, to avoid including it twice or including an excluded start value
+func (r *Range) includeStop() bool {
+	return ((r.rangeType & RANGE_INCLUDE_STOP) != 0) && r.to != r.from
+}
+
+// atStop checks if x is so close to the stop value that they are counted as equal when iterating,
+// so that the stop value is not included twice because of how floats are stored
+func (r *Range) atStop(x float64) bool {
+	return almostEqual(x, r.to, abs(r.step)*stepTolerance)
+}
+
 // ForEach calls the given function for each iteration in the range
 func (r *Range) ForEach(f func(float64)) {
 	x := r.from
-	if (r.rangeType & RANGE_INCLUDE_START) != 0 {
+	if r.includeStart() {
 		if x == r.from {
 			f(x)
 		}
 	}
 	x += r.step
 	if r.step > 0 {
-		for x < r.to && x > r.from {
+		for x < r.to && x > r.from && !r.atStop(x) {
 			f(x)
 			x += r.step
 		}
 	} else if r.step < 0 {
-		for x > r.to && x < r.from {
+		for x > r.to && x < r.from && !r.atStop(x) {
 			f(x)
 			x += r.step
 		}
 	}
-	if (r.rangeType & RANGE_INCLUDE_STOP) != 0 {
+	if r.includeStop() {
 		// But first check that it is within range
 		f(r.to)
 	}
@@ -529,7 +735,7 @@ func (r *Range) ForEach(f func(float64)) {
 // If the given function returns true, the remaining iterations are skipped
 func (r *Range) ForEachWithBreak(f func(float64) bool) {
 	x := r.from
-	if (r.rangeType & RANGE_INCLUDE_START) != 0 {
+	if r.includeStart() {
 		if x == r.from {
 			if f(x) {
 				// Break
@@ -539,7 +745,7 @@ func (r *Range) ForEachWithBreak(f func(float64) bool) {
 	}
 	x += r.step
 	if r.step > 0 {
-		for x < r.to && x > r.from {
+		for x < r.to && x > r.from && !r.atStop(x) {
 			if f(x) {
 				// Break
 				return
@@ -547,7 +753,7 @@ func (r *Range) ForEachWithBreak(f func(float64) bool) {
 			x += r.step
 		}
 	} else if r.step < 0 {
-		for x > r.to && x < r.from {
+		for x > r.to && x < r.from && !r.atStop(x) {
 			if f(x) {
 				// Break
 				return
@@ -555,7 +761,7 @@ func (r *Range) ForEachWithBreak(f func(float64) bool) {
 			x += r.step
 		}
 	}
-	if (r.rangeType & RANGE_INCLUDE_STOP) != 0 {
+	if r.includeStop() {
 		// But first check that it is within range
 		f(r.to) // Nothing to break out of at this point
 	}
@@ -566,7 +772,7 @@ func (r *Range) ForEachWithBreak(f func(float64) bool) {
 func (r *Range) ForN(n int, f func(float64)) {
 	counter := 0
 	x := r.from
-	if (r.rangeType & RANGE_INCLUDE_START) != 0 {
+	if r.includeStart() {
 		if x == r.from {
 			f(x)
 			counter++
@@ -577,7 +783,7 @@ func (r *Range) ForN(n int, f func(float64)) {
 	}
 	x += r.step
 	if r.step > 0 {
-		for x < r.to && x > r.from {
+		for x < r.to && x > r.from && !r.atStop(x) {
 			f(x)
 			counter++
 			if counter >= n {
@@ -586,7 +792,7 @@ func (r *Range) ForN(n int, f func(float64)) {
 			x += r.step
 		}
 	} else if r.step < 0 {
-		for x > r.to && x < r.from {
+		for x > r.to && x < r.from && !r.atStop(x) {
 			f(x)
 			counter++
 			if counter >= n {
@@ -595,7 +801,7 @@ func (r *Range) ForN(n int, f func(float64)) {
 			x += r.step
 		}
 	}
-	if (r.rangeType & RANGE_INCLUDE_STOP) != 0 {
+	if r.includeStop() {
 		f(r.to)
 	}
 }
@@ -618,8 +824,12 @@ func New(rangeExpression string) *Range {
 	}
 }
 
-// All returns a slice of numbers, generated from the range
+// All returns a slice of numbers, generated from the range.
+// Panics with ErrUnbounded if the range has no start or no stop value, like "5.." in Rust.
 func (r *Range) All() []float64 {
+	if r.unbounded() {
+		panic(ErrUnbounded)
+	}
 	var xs []float64
 	r.ForEach(func(x float64) {
 		xs = append(xs, x)
@@ -627,43 +837,20 @@ func (r *Range) All() []float64 {
 	return xs
 }
 
-// Slice2 can be used to slice a slice with a range
+// Slice2 can be used to slice a slice with a range.
+// Negative and "^n" indices count from the end, and bounds that are out of range are truncated, see SliceIndices.
 // Returns an error if the given expression is invalid
 func Slice2(nums []float64, expression string) ([]float64, error) {
-	var (
-		selection []float64
-		pos       int
-	)
-
-	r, err := New2(expression)
-	if err != nil {
-		return selection, err
-	}
-
-	r.ForEach(func(x float64) {
-		pos = int(x)
-		if pos < len(nums) {
-			selection = append(selection, nums[pos])
-		}
-	})
-	return selection, nil
+	return SliceOf(nums, expression)
 }
 
 // Slice can be used to slice a slice with a range
 // Will panic if the given expresion is invalid
 func Slice(nums []float64, expression string) []float64 {
-	var (
-		selection []float64
-		pos       int
-	)
-
-	r := New(expression)
-	r.ForEach(func(x float64) {
-		pos = int(x)
-		if pos < len(nums) {
-			selection = append(selection, nums[pos])
-		}
-	})
+	selection, err := Slice2(nums, expression)
+	if err != nil {
+		panic(err)
+	}
 	return selection
 }
 
@@ -680,10 +867,15 @@ func (r *Range) Take(n int) []float64 {
 // Join returns the output from the range as a string, where elements are separated by sep
 // digits are how many digits should be added to the fractional part of the floats,
 // use 0 for integers
+// Ranges of characters are joined as characters, and zero padded ranges are joined with zero padding.
 func (r *Range) Join(sep string, digits int) string {
 	var buf bytes.Buffer
 	r.ForEach(func(x float64) {
-		buf.WriteString(strconv.FormatFloat(x, 'f', digits, 64))
+		if r.runes {
+			buf.WriteRune(rune(x))
+		} else {
+			buf.WriteString(zeroPad(strconv.FormatFloat(x, 'f', digits, 64), r.width))
+		}
 		buf.WriteString(sep)
 	})
 	if buf.Len() == 0 {
@@ -694,6 +886,18 @@ func (r *Range) Join(sep string, digits int) string {
 	return s[:len(s)-len(sep)]
 }
 
+// zeroPad adds zeros after the sign of the given number, until it is at least the given width
+func zeroPad(number string, width int) string {
+	if len(number) >= width {
+		return number
+	}
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	return sign + strings.Repeat("0", width-len(sign)-len(number)) + number
+}
+
 // JoinInts returns the output from the range as a string, where elements are separated by sep
 func (r *Range) JoinInts(sep string) string {
 	return r.Join(sep, 0)
@@ -708,11 +912,18 @@ func (r *Range) Sum() float64 {
 	return sum
 }
 
-// Len returns the length of the range by iterating over it!
+// Len64 returns the length of the range as a float64, by counting integers or by iterating over it.
+// Returns +Inf if the range has no start or no stop value, like "5.." in Rust.
 // May get stuck if the range is impossibly large.
 func (r *Range) Len64() float64 {
-	if r.step == 1.0 {
-		return max(r.from, r.to) - min(r.from, r.to)
+	if r.IsEmpty() {
+		return 0
+	}
+	if r.unbounded() {
+		return math.Inf(1)
+	}
+	if n, ok := r.integerLen(); ok {
+		return n
 	}
 	// TODO: Optimize for ranges where there is no need to actually iterate
 	var counter uint64
@@ -722,11 +933,28 @@ func (r *Range) Len64() float64 {
 	return float64(counter)
 }
 
-// Len returns the length of the range by iterating over it!
+// integerLen counts the numbers in the range without iterating, if the step size is 1 or -1
+// and both the start and stop values are whole numbers. Returns false for all other ranges,
+// like "0..2.5", where 2.5 is also included.
+func (r *Range) integerLen() (float64, bool) {
+	if !r.Integer() || r.from != math.Trunc(r.from) || r.to != math.Trunc(r.to) {
+		return 0, false
+	}
+	return max((r.Last()-r.First())*r.step+1, 0), true
+}
+
+// Len returns the length of the range, by counting integers or by iterating over it!
+// Returns MaxUint if the range has no start or no stop value, like "5.." in Rust.
 // May get stuck if the range is impossibly large.
 func (r *Range) Len() uint {
-	if r.step == 1.0 {
-		return uint(max(r.from, r.to) - min(r.from, r.to))
+	if r.IsEmpty() {
+		return 0
+	}
+	if r.unbounded() {
+		return MaxUint
+	}
+	if n, ok := r.integerLen(); ok {
+		return uint(n)
 	}
 	// TODO: Optimize for additional ranges where there is no need to actually iterate
 	var counter uint
@@ -736,9 +964,29 @@ func (r *Range) Len() uint {
 	return counter
 }
 
-// Bits returns the number of bits required to hold the range
+// Bits returns the number of bits required to hold the range,
+// or -1 if the range has no start or no stop value, since no number of bits is enough.
 func (r *Range) Bits() int {
-	return int(math.Ceil(math.Log2(float64(r.Len()))))
+	if r.unbounded() {
+		return -1
+	}
+	if r.precision > 0 {
+		// A floating point number needs enough mantissa bits for the significant digits,
+		// enough exponent bits to reach the largest number and a sign bit if there are negative numbers.
+		mantissa := int(math.Ceil(float64(r.precision) * math.Log2(10)))
+		exponent := max(math.Ceil(math.Log2(max(abs(r.from), abs(r.to)))), 1)
+		bits := mantissa + int(math.Ceil(math.Log2(2*exponent+1)))
+		if min(r.from, r.to) < 0 {
+			bits++
+		}
+		return bits
+	}
+	n := r.Len64()
+	if n <= 1 {
+		// A range with a single number does not need any bits to hold it
+		return 0
+	}
+	return int(math.Ceil(math.Log2(n)))
 }
 
 // The following functions work, but is a bit unintuitive.
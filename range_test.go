@@ -149,6 +149,22 @@ func TestMinusOneOperator(t *testing.T) {
 	assert.Equal(t, IntType.Valid(42), true)
 }
 
+func TestLen(t *testing.T) {
+	// Both inclusive stop values and exclusive stop values are counted correctly
+	assert.Equal(t, New("0..255").Len(), uint(256))
+	assert.Equal(t, New("[0,256)").Len(), uint(256))
+	assert.Equal(t, New("3..0 step -1").Len(), uint(4))
+
+	// The length is the number of numbers in the range, also when the bounds are not whole numbers
+	for _, exp := range []string{"0..2.5", "(1,2)", "(0.5,3]", "(1,2]", "[0.5,3.5]"} {
+		r := New(exp)
+		assert.Equal(t, r.Len(), uint(len(r.All())))
+		assert.Equal(t, r.Len64(), float64(len(r.All())))
+	}
+	assert.Equal(t, New("0..2.5").Len(), uint(4))
+	assert.Equal(t, New("(1,2)").Len(), uint(0))
+}
+
 func TestFloatBits(t *testing.T) {
 	SmallFloat := New("[0:1.0:0.1)")
 
@@ -173,6 +189,15 @@ func TestFloatBits(t *testing.T) {
 	assert.Equal(t, SmallFloat3.Bits(), 5)
 }
 
+func TestValidHalfStep(t *testing.T) {
+	// Valid counts numbers within half a step of a number in the range as equal
+	r := New("0..0.5 step 0.1")
+	assert.Equal(t, r.Valid(0.35), true)
+	assert.Equal(t, r.Valid(0.6), false)
+	assert.Equal(t, r.validStep(0.35), false)
+	assert.Equal(t, r.validStep(0.3), true)
+}
+
 func TestSmallInt(t *testing.T) {
 	Integer8 := New("-2**7 .. 2**7~")
 	assert.Equal(t, Integer8.Valid(100), true)
@@ -204,3 +229,32 @@ func TestAda(t *testing.T) {
 	Integer := NewAda("0 .. Integer'Last")
 	assert.Equal(t, Integer.Len64(), float64(MaxInt))
 }
+
+func TestSingleNumber(t *testing.T) {
+	assert.Equal(t, New("2..2").Take(5), []float64{2})
+	// The number is only included if both the start and the stop are inclusive
+	for exp, expected := range map[string][]float64{
+		"[2,2]": {2},
+		"(2,2]": nil,
+		"[2,2)": nil,
+		"(2,2)": nil,
+	} {
+		r := New(exp)
+		assert.Equal(t, r.All(), expected)
+		assert.Equal(t, r.Take(5), expected)
+		var xs []float64
+		r.ForEachWithBreak(func(x float64) bool {
+			xs = append(xs, x)
+			return false
+		})
+		assert.Equal(t, xs, expected)
+		assert.Equal(t, r.IsEmpty(), expected == nil)
+	}
+}
+
+func TestCommaRange(t *testing.T) {
+	// Without brackets, the bounds of a range with a comma are neither included nor excluded
+	assert.Equal(t, New("1,3").All(), []float64{2})
+	assert.Equal(t, New("[1,3]").All(), []float64{1, 2, 3})
+	assert.Equal(t, New("[1,3)").All(), []float64{1, 2})
+}
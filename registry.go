@@ -0,0 +1,144 @@
+package rangetype
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var ErrRedeclared = errors.New("TYPE IS ALREADY DECLARED")
+
+// registry contains named range types, so that Ada declarations and attributes
+// like U8'Last can refer to them. Names are case insensitive, like in Ada.
+var registry = struct {
+	sync.RWMutex
+	types map[string]registeredType
+}{types: make(map[string]registeredType)}
+
+// registeredType is a named range type, with names for the numbers if it is an enumeration
+type registeredType struct {
+	r     *Range
+	names []string
+}
+
+// same checks if the registered type has the same range and the same names for the numbers
+func (t registeredType) same(r *Range, names []string) bool {
+	if *t.r != *r || len(t.names) != len(names) {
+		return false
+	}
+	for i, name := range names {
+		if !strings.EqualFold(t.names[i], name) {
+			return false
+		}
+	}
+	return true
+}
+
+// The predefined types are registered when the package is initialized,
+// since they are also evaluated when the package is initialized
+func init() {
+	for name, r := range map[string]*Range{
+		"U4":   U4,
+		"U8":   U8,
+		"U16":  U16,
+		"U32":  U32,
+		"U64":  U64,
+		"U128": U128,
+
+		"Nibble": Nibble,
+		"Char":   Char,
+		"Byte":   Byte,
+		"Word":   Word,
+		"Short":  Short,
+		"Long":   Long,
+		"Double": Double,
+		"Quad":   Quad,
+
+		"I8":   I8,
+		"I16":  I16,
+		"I32":  I32,
+		"I64":  I64,
+		"I128": I128,
+
+		// Predefined Ada types
+		"Integer":  {rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: float64(MinInt), to: float64(MaxInt), step: 1},
+		"Natural":  {rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: 0, to: float64(MaxInt), step: 1},
+		"Positive": {rangeType: RANGE_INCLUDE_START | RANGE_INCLUDE_STOP, from: 1, to: float64(MaxInt), step: 1},
+	} {
+		Register(name, r)
+	}
+}
+
+// Register adds a named range type, that can then be used in Ada expressions, like "Name'Last".
+// Returns an error if a different type with the same name already exists, including the predefined types.
+// Registering the same type again is allowed.
+func Register(name string, r *Range) error {
+	return register(name, r, nil)
+}
+
+// RegisterEnum adds a named enumeration type, so that both the type and the names of its numbers
+// can be used in Ada expressions. Returns an error if a different type with the same name already exists.
+func RegisterEnum(name string, e *Enum) error {
+	return register(name, e.Range, e.names)
+}
+
+// register adds a named range type, with names for the numbers if it is an enumeration
+func register(name string, r *Range, names []string) error {
+	registry.Lock()
+	defer registry.Unlock()
+	key := strings.ToLower(name)
+	if existing, ok := registry.types[key]; ok {
+		if existing.same(r, names) {
+			// Declaring the same type again is allowed, like when a configuration is loaded again
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrRedeclared, name)
+	}
+	registry.types[key] = registeredType{r, names}
+	return nil
+}
+
+// Lookup returns the range type with the given name, if it has been registered
+func Lookup(name string) (*Range, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	t, ok := registry.types[strings.ToLower(name)]
+	return t.r, ok
+}
+
+// LookupEnum returns the enumeration type with the given name, if it has been registered
+func LookupEnum(name string) (*Enum, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	t, ok := registry.types[strings.ToLower(name)]
+	if !ok || t.names == nil {
+		return nil, false
+	}
+	return &Enum{t.r, t.names}, true
+}
+
+// lookupLiteral returns the position of the given enumeration literal, like "Red" in "type Color is (Red, Green, Blue);".
+// Returns an error if the literal is not found, or if it has different positions in different enumerations.
+func lookupLiteral(literal string) (float64, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	found := false
+	var position int
+	for _, t := range registry.types {
+		for i, name := range t.names {
+			if !strings.EqualFold(name, literal) {
+				continue
+			}
+			if found && i != position {
+				return 0, errors.New("AMBIGUOUS NAME: " + literal)
+			}
+			found = true
+			position = i
+		}
+	}
+	if !found {
+		return 0, errors.New("UNKNOWN NAME: " + literal)
+	}
+	return float64(position), nil
+}
@@ -0,0 +1,46 @@
+package rangetype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r, ok := Lookup("u8")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, r, U8)
+
+	assert.Equal(t, Register("Percent", New("0..100")), nil)
+	r, ok = Lookup("percent")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, r.Last(), 100.0)
+	assert.Equal(t, NewAda("Percent'First .. Percent'Last / 2").Last(), 50.0)
+
+	// Declaring the same type again is allowed, like when a configuration is loaded again
+	assert.Equal(t, Register("Percent", New("0..100")), nil)
+	assert.Equal(t, Register("U8", New("0..255")), nil)
+	for i := 0; i < 2; i++ {
+		_, err := NewAda2("type Level is delta 0.5 range 0.0 .. 10.0;")
+		assert.Equal(t, err, nil)
+		_, err = NewEnum2("type Switch is (Off, On);")
+		assert.Equal(t, err, nil)
+	}
+
+	// Existing and predefined types can not be declared as different types
+	assert.Equal(t, errors.Is(Register("PERCENT", New("0..10")), ErrRedeclared), true)
+	assert.Equal(t, errors.Is(Register("U8", New("0..10")), ErrRedeclared), true)
+	_, err := NewAda2("type Integer is range 0 .. 10;")
+	assert.Equal(t, errors.Is(err, ErrRedeclared), true)
+	_, err = NewEnum2("type Percent is (Low, High);")
+	assert.Equal(t, errors.Is(err, ErrRedeclared), true)
+	r, _ = Lookup("Integer")
+	assert.Equal(t, r.First(), float64(MinInt))
+
+	// A declaration that fails is not registered
+	_, err = NewEnum2("type Mass is range 0 .. 10;")
+	assert.NotEqual(t, err, nil)
+	_, ok = Lookup("Mass")
+	assert.Equal(t, ok, false)
+}
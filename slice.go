@@ -0,0 +1,298 @@
+package rangetype
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSliceStep   = errors.New("SLICE STEP CAN NOT BE ZERO")
+	ErrSliceBounds = errors.New("SLICE INDEX OUT OF RANGE")
+)
+
+// SliceIndices returns the positions that a slice expression selects from a slice with the given length.
+//
+// The bounds of the expression are resolved against the length, like in Python and C#:
+// negative indices count from the end, so that "-3:" selects the last three elements,
+// and "^n" is the n-th position from the end, so that "^3.." also selects the last three elements.
+// Missing bounds are the start or the end of the slice, and a negative step walks backwards, like for "::-1".
+//
+// Expressions with a "^n" bound follow C#, where the stop index of ".." is exclusive, unless "]" is given.
+//
+// Bounds that are out of range are truncated to the slice, like in Python.
+func SliceIndices(expression string, length int) ([]int, error) {
+	return sliceIndices(expression, length, false)
+}
+
+// SliceIndicesStrict is like SliceIndices, but returns ErrSliceBounds
+// if a bound is out of range, instead of truncating it.
+func SliceIndicesStrict(expression string, length int) ([]int, error) {
+	return sliceIndices(expression, length, true)
+}
+
+// sliceIndices returns the positions that a slice expression selects from a slice with the given length
+func sliceIndices(expression string, length int, strict bool) ([]int, error) {
+	positions, err := resolveSlice(expression, length, strict)
+	if err != nil {
+		return nil, err
+	}
+	return positions.indices(), nil
+}
+
+// slicePositions is a slice expression that has been resolved against the length of a slice,
+// where start is the first position and stop is the exclusive end position, like for slice.indices in Python
+type slicePositions struct {
+	start, stop, step int
+}
+
+// resolveSlice resolves the bounds of a slice expression against the given slice length
+func resolveSlice(expression string, length int, strict bool) (slicePositions, error) {
+	var none slicePositions
+	parts, err := splitRange(expression, false)
+	if err != nil {
+		return none, err
+	}
+
+	step := 1
+	if parts.step != "" {
+		if step, err = sliceInt(parts.step, float64(length)+1); err != nil {
+			return none, errors.New("INVALID STEP SIZE: " + parts.step + ", " + err.Error())
+		}
+		if step == 0 {
+			return none, ErrSliceStep
+		}
+	}
+
+	// The position of the first and the last element, if the bounds are missing
+	start, stop := 0, length
+	if step < 0 {
+		start, stop = length-1, -1
+	}
+
+	if parts.left != "" {
+		if start, err = sliceIndex(parts.left, length); err != nil {
+			return none, err
+		}
+		if (parts.rangeType & RANGE_INCLUDE_START) == 0 {
+			// Like for ForEach, the start is only included if it is marked as inclusive
+			start += sign(step)
+		}
+	}
+
+	if parts.right != "" {
+		if stop, err = sliceIndex(parts.right, length); err != nil {
+			return none, err
+		}
+		inclusive := (parts.rangeType & RANGE_INCLUDE_STOP) != 0
+		fromEnd := strings.HasPrefix(parts.left, "^") || strings.HasPrefix(parts.right, "^")
+		if parts.separator == ".." && fromEnd && !strings.Contains(expression, "]") {
+			// C# style, as in x[^3..^1]
+			inclusive = false
+		}
+		if inclusive {
+			stop += sign(step)
+		}
+	}
+
+	// The start and stop positions may be one position before or after the slice,
+	// depending on the direction.
+	lower, upper := 0, length
+	if step < 0 {
+		lower, upper = -1, length-1
+	}
+	if strict && (start < lower || start > upper || stop < lower || stop > upper) {
+		return none, ErrSliceBounds
+	}
+	start = clamp(start, lower, upper)
+	stop = clamp(stop, lower, upper)
+
+	return slicePositions{start, stop, step}, nil
+}
+
+// indices returns the selected positions
+func (p slicePositions) indices() []int {
+	var indices []int
+	for i := p.start; (p.step > 0 && i < p.stop) || (p.step < 0 && i > p.stop); i += p.step {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// sliceIndex evaluates a bound of a slice expression and resolves negative and "^n" indices against the given length.
+// Bounds that are far out of range are limited to twice the length, so that they are still out of range.
+func sliceIndex(bound string, length int) (int, error) {
+	fromEnd := strings.HasPrefix(bound, "^")
+	if fromEnd {
+		bound = bound[1:]
+	}
+	i, err := sliceInt(bound, 2*float64(length)+2)
+	if err != nil {
+		return 0, err
+	}
+	if fromEnd {
+		if i < 0 {
+			return 0, errors.New("INVALID INDEX: ^" + bound)
+		}
+		return length - i, nil
+	}
+	if i < 0 {
+		return length + i, nil
+	}
+	return i, nil
+}
+
+// sliceInt evaluates a bound or a step size of a slice expression as an integer, limited to the interval [-limit, limit]
+func sliceInt(exp string, limit float64) (int, error) {
+	x, err := eval(exp, false)
+	if err != nil {
+		return 0, err
+	}
+	if math.Trunc(x) != x {
+		return 0, errors.New("INVALID INDEX: " + strconv.FormatFloat(x, 'f', -1, 64))
+	}
+	return int(math.Max(-limit, math.Min(x, limit))), nil
+}
+
+// sign returns 1 for positive numbers and -1 for negative numbers
+func sign(i int) int {
+	if i < 0 {
+		return -1
+	}
+	return 1
+}
+
+// clamp returns i, limited to the interval [a, b]
+func clamp(i, a, b int) int {
+	if i < a {
+		return a
+	}
+	if i > b {
+		return b
+	}
+	return i
+}
+
+// SliceStrict can be used to slice a slice with a range.
+// Returns ErrSliceBounds if a bound is out of range, or an error if the given expression is invalid.
+func SliceStrict(nums []float64, expression string) ([]float64, error) {
+	indices, err := SliceIndicesStrict(expression, len(nums))
+	if err != nil {
+		return nil, err
+	}
+	return pick(nums, indices), nil
+}
+
+// SliceOf can be used to slice a slice of any type with a range, like Slice2 does for float64 slices.
+// The selected elements are copied to a new slice.
+// Returns an error if the given expression is invalid
+func SliceOf[T any](xs []T, expression string) ([]T, error) {
+	indices, err := SliceIndices(expression, len(xs))
+	if err != nil {
+		return nil, err
+	}
+	return pick(xs, indices), nil
+}
+
+// SliceString can be used to slice a string with a range, where the positions are runes (characters),
+// like for strings in Python. For example, "::-1" reverses the string.
+// Returns an error if the given expression is invalid
+func SliceString(s, expression string) (string, error) {
+	runes, err := SliceOf([]rune(s), expression)
+	if err != nil {
+		return "", err
+	}
+	return string(runes), nil
+}
+
+// SliceBytes can be used to slice a string with a range, where the positions are bytes.
+// Note that slicing may split UTF-8 encoded characters.
+// Returns an error if the given expression is invalid
+func SliceBytes(s, expression string) (string, error) {
+	bs, err := SliceOf([]byte(s), expression)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// SliceDelete returns a copy of xs, where the elements that are selected by the range expression are removed,
+// like "del xs[1:10:2]" in Python. xs is not modified.
+// Returns an error if the given expression is invalid
+func SliceDelete[T any](xs []T, expression string) ([]T, error) {
+	indices, err := SliceIndices(expression, len(xs))
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]bool, len(xs))
+	for _, i := range indices {
+		selected[i] = true
+	}
+	result := make([]T, 0, len(xs)-len(indices))
+	for i, x := range xs {
+		if !selected[i] {
+			result = append(result, x)
+		}
+	}
+	return result, nil
+}
+
+// SliceAssign returns a copy of xs, where the elements that are selected by the range expression
+// are replaced with the given elements, like "xs[0:3] = other" in Python. xs is not modified.
+//
+// If the step size is 1, the selected elements may be replaced with any number of elements,
+// so that the slice may grow or shrink. If the selection is empty, the elements are inserted at the start position.
+// For other step sizes, like for "::2", the number of elements must match the number of selected elements.
+//
+// Returns an error if the given expression is invalid, or if the number of elements does not match.
+func SliceAssign[T any](xs []T, expression string, elements []T) ([]T, error) {
+	positions, err := resolveSlice(expression, len(xs), false)
+	if err != nil {
+		return nil, err
+	}
+	if positions.step == 1 {
+		start, stop := positions.start, positions.stop
+		if stop < start {
+			stop = start
+		}
+		result := make([]T, 0, len(xs)-(stop-start)+len(elements))
+		result = append(result, xs[:start]...)
+		result = append(result, elements...)
+		return append(result, xs[stop:]...), nil
+	}
+	indices := positions.indices()
+	if len(elements) != len(indices) {
+		return nil, fmt.Errorf("ATTEMPT TO ASSIGN SEQUENCE OF SIZE %d TO EXTENDED SLICE OF SIZE %d", len(elements), len(indices))
+	}
+	result := append([]T(nil), xs...)
+	for j, i := range indices {
+		result[i] = elements[j]
+	}
+	return result, nil
+}
+
+// SliceReplace returns a copy of xs, where each element that is selected by the range expression
+// is replaced with the result of calling f with that element. xs is not modified.
+// Returns an error if the given expression is invalid
+func SliceReplace[T any](xs []T, expression string, f func(T) T) ([]T, error) {
+	indices, err := SliceIndices(expression, len(xs))
+	if err != nil {
+		return nil, err
+	}
+	result := append([]T(nil), xs...)
+	for _, i := range indices {
+		result[i] = f(xs[i])
+	}
+	return result, nil
+}
+
+// pick returns the elements at the given positions
+func pick[T any](xs []T, indices []int) []T {
+	var selection []T
+	for _, i := range indices {
+		selection = append(selection, xs[i])
+	}
+	return selection
+}
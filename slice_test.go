@@ -0,0 +1,221 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestSliceIndices(t *testing.T) {
+	for exp, expected := range map[string][]int{
+		"0:5":      {0, 1, 2, 3, 4},
+		"1..3":     {1, 2, 3},
+		"(0,3)":    {1, 2},
+		"1,3":      {2},
+		"-3:":      {2, 3, 4},
+		":-3":      {0, 1},
+		"-3..-1":   {2, 3, 4},
+		"^3..":     {2, 3, 4},
+		"..^3":     {0, 1},
+		"1..^1":    {1, 2, 3},
+		"[1..^1]":  {1, 2, 3, 4},
+		"^2..^0":   {3, 4},
+		"::-1":     {4, 3, 2, 1, 0},
+		"::2":      {0, 2, 4},
+		"-1::-2":   {4, 2, 0},
+		"3:1:-1":   {3, 2},
+		"[3:1:-1]": {3, 2, 1},
+		"1:100":    {1, 2, 3, 4},
+		"-100:2":   {0, 1},
+		"3:1":      nil,
+		"5:":       nil,
+
+		// Bounds and step sizes that are far out of range are truncated, like in Python
+		"-10000000000:":   {0, 1, 2, 3, 4},
+		"0:10000000000":   {0, 1, 2, 3, 4},
+		"::10000000000":   {0},
+		"::-10000000000":  {4},
+		"10000000000::-1": {4, 3, 2, 1, 0},
+	} {
+		indices, err := SliceIndices(exp, 5)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, indices, expected)
+	}
+
+	for _, exp := range []string{"1:2:0", "0:1.5", "a:3", "1", "^-1.."} {
+		_, err := SliceIndices(exp, 5)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestSliceIndicesStrict(t *testing.T) {
+	for exp, expected := range map[string][]int{
+		"-3:":  {2, 3, 4},
+		"0:5":  {0, 1, 2, 3, 4},
+		"^5..": {0, 1, 2, 3, 4},
+		"::-1": {4, 3, 2, 1, 0},
+		"5:":   nil,
+	} {
+		indices, err := SliceIndicesStrict(exp, 5)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, indices, expected)
+	}
+	for _, exp := range []string{"1:100", "-6:", "^6..", "0..5", "6::-1", "0:10000000000", "-10000000000:", "-10000000000::-1"} {
+		_, err := SliceIndicesStrict(exp, 5)
+		assert.Equal(t, err, ErrSliceBounds)
+	}
+}
+
+func TestSliceFromEnd(t *testing.T) {
+	nums := []float64{1, 2, 3, 4, 5}
+	assert.Equal(t, Slice(nums, "-3:"), []float64{3, 4, 5})
+	assert.Equal(t, Slice(nums, "^3.."), []float64{3, 4, 5})
+	assert.Equal(t, Slice(nums, "::-1"), []float64{5, 4, 3, 2, 1})
+	assert.Equal(t, Slice(nums, "2..10"), []float64{3, 4, 5})
+
+	_, err := SliceStrict(nums, "2..10")
+	assert.Equal(t, err, ErrSliceBounds)
+	selection, err := SliceStrict(nums, "2..4")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, selection, []float64{3, 4, 5})
+}
+
+func TestSliceOf(t *testing.T) {
+	words := []string{"zero", "one", "two", "three", "four"}
+	selection, err := SliceOf(words, "1..3 step 2")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, selection, []string{"one", "three"})
+	selection, err = SliceOf(words, "::-2")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, selection, []string{"four", "two", "zero"})
+	_, err = SliceOf(words, "1:2:0")
+	assert.Equal(t, err, ErrSliceStep)
+
+	type point struct{ x, y int }
+	points, err := SliceOf([]point{{1, 2}, {3, 4}, {5, 6}}, "-2:")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, points, []point{{3, 4}, {5, 6}})
+
+	// The selection is a copy
+	ints := []int{1, 2, 3}
+	selected, err := SliceOf(ints, ":2")
+	assert.Equal(t, err, nil)
+	selected[0] = 42
+	assert.Equal(t, ints[0], 1)
+}
+
+func TestSliceString(t *testing.T) {
+	for exp, expected := range map[string]string{
+		"::-1":  "dlrøw olleh",
+		"0:5":   "hello",
+		"-5:":   "wørld",
+		"^5..":  "wørld",
+		"::2":   "hlowrd",
+		"7..7":  "ø",
+		"20:30": "",
+	} {
+		s, err := SliceString("hello wørld", exp)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, s, expected)
+	}
+
+	s, err := SliceBytes("hello wørld", "-4:")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s, "\xb8rld")
+	s, err = SliceBytes("hello wørld", "6..8")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s, "wø")
+
+	_, err = SliceString("hello", "a:b")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestSliceDelete(t *testing.T) {
+	xs := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	for _, test := range []struct {
+		exp      string
+		expected []int
+	}{
+		{"1:10:2", []int{0, 2, 4, 6, 8}},
+		{"::2", []int{1, 3, 5, 7, 9}},
+		{"2:5", []int{0, 1, 5, 6, 7, 8, 9}},
+		{"2..5", []int{0, 1, 6, 7, 8, 9}},
+		{"-2:", []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"::-3", []int{1, 2, 4, 5, 7, 8}},
+		{"5:2", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{":", []int{}},
+		{"20:30", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	} {
+		result, err := SliceDelete(xs, test.exp)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result, test.expected)
+	}
+	assert.Equal(t, xs, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	_, err := SliceDelete(xs, "::0")
+	assert.Equal(t, err, ErrSliceStep)
+}
+
+func TestSliceAssign(t *testing.T) {
+	xs := []string{"a", "b", "c", "d", "e"}
+	for _, test := range []struct {
+		exp      string
+		elements []string
+		expected []string
+	}{
+		// Simple slices may grow or shrink the slice
+		{"0:3", []string{"x"}, []string{"x", "d", "e"}},
+		{"1:2", []string{"x", "y", "z"}, []string{"a", "x", "y", "z", "c", "d", "e"}},
+		{"1:1", []string{"x"}, []string{"a", "x", "b", "c", "d", "e"}},
+		{"3:1", []string{"x"}, []string{"a", "b", "c", "x", "d", "e"}},
+		{"10:", []string{"x"}, []string{"a", "b", "c", "d", "e", "x"}},
+		{"-2:", nil, []string{"a", "b", "c"}},
+		{":", []string{"x"}, []string{"x"}},
+		{"::1", []string{"x"}, []string{"x"}},
+		{"^1..", []string{"x", "y"}, []string{"a", "b", "c", "d", "x", "y"}},
+		// Extended slices must have the same number of elements
+		{"::2", []string{"x", "y", "z"}, []string{"x", "b", "y", "d", "z"}},
+		{"::-1", []string{"v", "w", "x", "y", "z"}, []string{"z", "y", "x", "w", "v"}},
+		{"1::3", []string{"x", "y"}, []string{"a", "x", "c", "d", "y"}},
+		{"4:0:2", nil, []string{"a", "b", "c", "d", "e"}},
+	} {
+		result, err := SliceAssign(xs, test.exp, test.elements)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result, test.expected)
+	}
+	assert.Equal(t, xs, []string{"a", "b", "c", "d", "e"})
+
+	for _, test := range []struct {
+		exp      string
+		elements []string
+	}{
+		{"::2", []string{"x"}},
+		{"::-1", []string{"x", "y"}},
+		{"0:1:0", []string{"x"}},
+		{"a:b", []string{"x"}},
+	} {
+		_, err := SliceAssign(xs, test.exp, test.elements)
+		assert.NotEqual(t, err, nil)
+	}
+	_, err := SliceAssign(xs, "::2", []string{"x"})
+	assert.Equal(t, err.Error(), "ATTEMPT TO ASSIGN SEQUENCE OF SIZE 1 TO EXTENDED SLICE OF SIZE 3")
+}
+
+func TestSliceReplace(t *testing.T) {
+	xs := []int{1, 2, 3, 4, 5}
+	double := func(x int) int { return x * 2 }
+	for _, test := range []struct {
+		exp      string
+		expected []int
+	}{
+		{"::2", []int{2, 2, 6, 4, 10}},
+		{"-2:", []int{1, 2, 3, 8, 10}},
+		{"1..3", []int{1, 4, 6, 8, 5}},
+		{"3:1", []int{1, 2, 3, 4, 5}},
+	} {
+		result, err := SliceReplace(xs, test.exp, double)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result, test.expected)
+	}
+	assert.Equal(t, xs, []int{1, 2, 3, 4, 5})
+}
@@ -0,0 +1,76 @@
+package rangetype
+
+// predefinedType is a predefined integer type, with the corresponding types in Go and C
+type predefinedType struct {
+	name   string
+	r      *Range
+	goName string // empty if there is no built-in Go type
+	cName  string
+	size   int // in bytes
+}
+
+// predefinedTypes are the predefined integer types, from the smallest to the largest,
+// with unsigned types before signed types of the same size
+var predefinedTypes = []predefinedType{
+	{"U8", U8, "uint8", "uint8_t", 1},
+	{"I8", I8, "int8", "int8_t", 1},
+	{"U16", U16, "uint16", "uint16_t", 2},
+	{"I16", I16, "int16", "int16_t", 2},
+	{"U32", U32, "uint32", "uint32_t", 4},
+	{"I32", I32, "int32", "int32_t", 4},
+	{"U64", U64, "uint64", "uint64_t", 8},
+	{"I64", I64, "int64", "int64_t", 8},
+	{"U128", U128, "", "unsigned __int128", 16},
+	{"I128", I128, "", "__int128", 16},
+}
+
+// StorageType is the predefined integer type with the fewest bits that can hold all numbers in a range
+type StorageType struct {
+	Name   string // the name of the predefined type, like "U8"
+	Range  *Range // the predefined type, like U8
+	GoName string // the Go type, like "uint8", or an empty string for 128-bit types
+	CName  string // the C type, like "uint8_t"
+	Size   int    // the size in bytes
+
+	// Biased is true if storing x - Offset, instead of x, would need a smaller type,
+	// like for the range 1000..1200, where the numbers fit in an U8 when 1000 is subtracted.
+	Biased     bool
+	Offset     float64
+	BiasedType string // the name of the smaller predefined type, like "U8"
+	BiasedSize int    // the size of the smaller type, in bytes
+}
+
+// StorageType returns the predefined integer type with the fewest bits that contains all numbers in the range,
+// like U8 for "0..200" and I16 for "-200..200", with the names of the corresponding Go and C types.
+// It also checks if an offset encoding would make it possible to use a smaller type.
+// Returns false if the range is not an integer range, or if no predefined type is large enough.
+func (r *Range) StorageType() (StorageType, bool) {
+	t, ok := findPredefined(r)
+	if !ok {
+		return StorageType{}, false
+	}
+	st := StorageType{Name: t.name, Range: t.r, GoName: t.goName, CName: t.cName, Size: t.size}
+	lo, hi := r.bounds()
+	if biased, ok := findPredefined(resultRange(0, hi-lo, 1)); ok && biased.size < t.size {
+		st.Biased = true
+		st.Offset = lo
+		st.BiasedType = biased.name
+		st.BiasedSize = biased.size
+	}
+	return st, true
+}
+
+// findPredefined returns the smallest predefined type that contains all numbers in the range
+func findPredefined(r *Range) (predefinedType, bool) {
+	if r.IsEmpty() || !r.integers() {
+		return predefinedType{}, false
+	}
+	lo, hi := r.bounds()
+	for _, t := range predefinedTypes {
+		tlo, thi := t.r.bounds()
+		if lo >= tlo && hi <= thi {
+			return t, true
+		}
+	}
+	return predefinedType{}, false
+}
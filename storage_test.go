@@ -0,0 +1,84 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+func TestStorageType(t *testing.T) {
+	for _, test := range []struct {
+		exp, name, goName, cName string
+		size                     int
+	}{
+		{"0..200", "U8", "uint8", "uint8_t", 1},
+		{"-200..200", "I16", "int16", "int16_t", 2},
+		{"[0,256)", "U8", "uint8", "uint8_t", 1},
+		{"0..256", "U16", "uint16", "uint16_t", 2},
+		{"-1..0", "I8", "int8", "int8_t", 1},
+		{"0..2**32~", "U32", "uint32", "uint32_t", 4},
+		{"-2**31..2**31~", "I32", "int32", "int32_t", 4},
+		{"0..2**40", "U64", "uint64", "uint64_t", 8},
+		{"-2**100..0", "I128", "", "__int128", 16},
+		{"0..2**100", "U128", "", "unsigned __int128", 16},
+		{"7..7", "U8", "uint8", "uint8_t", 1},
+	} {
+		st, ok := New(test.exp).StorageType()
+		assert.Equal(t, ok, true)
+		assert.Equal(t, st.Name, test.name)
+		assert.Equal(t, st.GoName, test.goName)
+		assert.Equal(t, st.CName, test.cName)
+		assert.Equal(t, st.Size, test.size)
+		found, _ := Lookup(test.name)
+		assert.Equal(t, st.Range, found)
+	}
+
+	// An offset encoding makes it possible to use a smaller type
+	st, ok := New("1000..1200").StorageType()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, st.Name, "U16")
+	assert.Equal(t, st.Biased, true)
+	assert.Equal(t, st.Offset, 1000.0)
+	assert.Equal(t, st.BiasedType, "U8")
+	assert.Equal(t, st.BiasedSize, 1)
+
+	st, ok = New("-100000..-99000").StorageType()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, st.Name, "I32")
+	assert.Equal(t, st.Biased, true)
+	assert.Equal(t, st.Offset, -100000.0)
+	assert.Equal(t, st.BiasedType, "U16")
+
+	st, ok = New("1000..1300").StorageType()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, st.Biased, false)
+	st, ok = U8.StorageType()
+	assert.Equal(t, ok, true)
+	assert.Equal(t, st.Biased, false)
+
+	for _, r := range []*Range{New("0..1 step 0.5"), New("-2**128..0"), NewDialect("0..", DialectRust), empty(0)} {
+		_, ok := r.StorageType()
+		assert.Equal(t, ok, false)
+	}
+}
+
+func TestBitsAndLen(t *testing.T) {
+	for exp, expected := range map[string]int{
+		"7..7":          0,
+		"[7,8)":         0,
+		"0..1":          1,
+		"0..255":        8,
+		"0..256":        9,
+		"-5..-2":        2,
+		"10..1 step -1": 4,
+	} {
+		assert.Equal(t, New(exp).Bits(), expected)
+	}
+	assert.Equal(t, New("7..7").Len(), uint(1))
+	assert.Equal(t, New("0..255").Len(), uint(256))
+	assert.Equal(t, New("[0,256)").Len(), uint(256))
+	assert.Equal(t, New("(0,256)").Len64(), 255.0)
+	assert.Equal(t, New("10..1 step -1").Len(), uint(10))
+	assert.Equal(t, empty(3).Len(), uint(0))
+	assert.Equal(t, U16.Len64(), 65536.0)
+}
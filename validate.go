@@ -0,0 +1,181 @@
+package rangetype
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// float32Epsilon is the relative difference between a float32 and the next float32
+const float32Epsilon = 1.0 / (1 << 23)
+
+// FieldError is a struct field with a value that is not in the range that is given by the "range" tag
+type FieldError struct {
+	Path  string // like "Server.Ports[2]"
+	Value float64
+	Range *Range
+}
+
+// Error returns a message like "Workers: 100 must be in [1, 64]"
+func (e *FieldError) Error() string {
+	return e.Path + ": " + strconv.FormatFloat(e.Value, 'g', -1, 64) + " " + mustBeIn(e.Range).Error()
+}
+
+// ValidationErrors is a list of all the struct fields with values that are not in the allowed range
+type ValidationErrors []*FieldError
+
+// Error returns the messages for all of the fields, separated by "; "
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateStruct checks the int, uint and float fields of the given struct, or pointer to a struct,
+// that have a "range" tag with a range expression or the name of a registered type:
+//
+//	type Config struct {
+//		Volume  float64 `range:"[0,1]"`
+//		Workers int     `range:"1..64"`
+//		Level   uint    `range:"U8"`
+//	}
+//
+// Float fields with a range expression without a step size can have any value between the bounds,
+// and float32 fields are compared with the precision of a float32. Other values must be on a step in the range.
+// This is stricter than Range.Valid, which also accepts numbers within half a step of a number in the range,
+// so a field with the tag "0..1 step 0.25" can not be 0.3, even if Valid(0.3) returns true.
+// Tags on slices and arrays apply to each element, and nested structs, including structs in slices,
+// arrays and pointers, are also checked. Pointers back to a struct that is already being checked are not followed.
+//
+// Returns ValidationErrors with every field that is not in range, or an error if a tag is invalid.
+func ValidateStruct(v any) error {
+	c := &validator{visiting: make(map[visit]bool)}
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		c.visiting[visit{value.Pointer(), value.Type()}] = true
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return errors.New("NOT A STRUCT: " + value.Kind().String())
+	}
+	if err := c.validateStruct(value, ""); err != nil {
+		return err
+	}
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	return nil
+}
+
+// validator collects the struct fields that are not in range, and keeps track of the pointers
+// that are being followed, so that structs that refer to themselves are only checked once
+type validator struct {
+	errs     ValidationErrors
+	visiting map[visit]bool
+}
+
+// visit is a pointer that is being followed
+type visit struct {
+	p uintptr
+	t reflect.Type
+}
+
+// validateStruct checks the fields of a struct, and adds the fields that are not in range to the errors
+func (v *validator) validateStruct(value reflect.Value, path string) error {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		var r *Range
+		if tag, ok := field.Tag.Lookup("range"); ok {
+			var err error
+			if r, err = tagRange(tag, field.Type); err != nil {
+				return errors.New("INVALID RANGE TAG ON " + fieldPath + ": " + err.Error())
+			}
+		}
+		if err := v.validateValue(value.Field(i), fieldPath, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tagRange returns the range for a "range" tag, which is either the name of a registered type or a range expression
+func tagRange(tag string, t reflect.Type) (*Range, error) {
+	if r, ok := Lookup(strings.TrimSpace(tag)); ok {
+		return r, nil
+	}
+	// Find the element type of slices, arrays and pointers
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+		return newContinuous(tag)
+	}
+	return New2(tag)
+}
+
+// validateValue checks a value against the given range, if any, and checks nested structs
+func (v *validator) validateValue(value reflect.Value, path string, r *Range) error {
+	var x float64
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x = float64(value.Uint())
+	case reflect.Float64:
+		x = value.Float()
+	case reflect.Float32:
+		// Use the shortest decimal form of the float32, like 0.3 instead of 0.30000001192092896,
+		// and allow for the rounding errors of a float32, which has fewer significant digits than a float64.
+		x, _ = strconv.ParseFloat(strconv.FormatFloat(value.Float(), 'g', -1, 32), 64)
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		key := visit{value.Pointer(), value.Type()}
+		if v.visiting[key] {
+			// A pointer back to a value that is already being checked
+			return nil
+		}
+		v.visiting[key] = true
+		defer delete(v.visiting, key)
+		return v.validateValue(value.Elem(), path, r)
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return v.validateValue(value.Elem(), path, r)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := v.validateValue(value.Index(i), path+"["+strconv.Itoa(i)+"]", r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		return v.validateStruct(value, path)
+	default:
+		return nil
+	}
+	if r == nil {
+		return nil
+	}
+	threshold := abs(r.step) * stepTolerance
+	if value.Kind() == reflect.Float32 {
+		threshold = max(threshold, abs(x)*float32Epsilon)
+	}
+	if !r.validWithin(x, threshold) {
+		v.errs = append(v.errs, &FieldError{Path: path, Value: x, Range: r})
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package rangetype
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+type testServer struct {
+	Host  string
+	Ports []int `range:"1..65535"`
+}
+
+type testConfig struct {
+	Volume   float64 `range:"[0,1]"`
+	Workers  int     `range:"1..64"`
+	Level    uint    `range:"U8"`
+	Ratio    float32 `range:"[0,1)"`
+	Even     int     `range:"0..10 step 2"`
+	Servers  []testServer
+	Backup   *testServer
+	Untagged int
+	hidden   int `range:"1..2"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	valid := testConfig{
+		Volume:  0.5,
+		Workers: 4,
+		Level:   255,
+		Ratio:   0.25,
+		Even:    4,
+		Servers: []testServer{{"a", []int{80, 443}}},
+		Backup:  &testServer{"b", []int{8080}},
+	}
+	assert.Equal(t, ValidateStruct(valid), nil)
+	assert.Equal(t, ValidateStruct(&valid), nil)
+
+	invalid := valid
+	invalid.Volume = 1.5
+	invalid.Workers = 100
+	invalid.Level = 256
+	invalid.Even = 3
+	invalid.Servers = []testServer{{"a", []int{80}}, {"b", []int{443, 0, 70000}}}
+	invalid.Backup = &testServer{"c", []int{-1}}
+	err := ValidateStruct(&invalid)
+	errs, ok := err.(ValidationErrors)
+	assert.Equal(t, ok, true)
+	var paths []string
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, paths, []string{"Volume", "Workers", "Level", "Even", "Servers[1].Ports[1]", "Servers[1].Ports[2]", "Backup.Ports[0]"})
+	assert.Equal(t, errs[1].Value, 100.0)
+	assert.Equal(t, errs[1].Range.Last(), 64.0)
+	assert.Equal(t, errs[1].Error(), "Workers: 100 must be in [1, 64]")
+	assert.Equal(t, errs[3].Error(), "Even: 3 must be in [0, 10], with step 2")
+	assert.Equal(t, err.Error(), "Volume: 1.5 must be in [0, 1]; Workers: 100 must be in [1, 64]; Level: 256 must be in [0, 255]; "+
+		"Even: 3 must be in [0, 10], with step 2; Servers[1].Ports[1]: 0 must be in [1, 65535]; "+
+		"Servers[1].Ports[2]: 70000 must be in [1, 65535]; Backup.Ports[0]: -1 must be in [1, 65535]")
+
+	var badTag struct {
+		X int `range:"1.."`
+	}
+	assert.NotEqual(t, ValidateStruct(badTag), nil)
+	_, ok = ValidateStruct(badTag).(ValidationErrors)
+	assert.Equal(t, ok, false)
+
+	assert.NotEqual(t, ValidateStruct(42), nil)
+	assert.NotEqual(t, ValidateStruct(nil), nil)
+}
+
+func TestValidateSteps(t *testing.T) {
+	// Values must be on a step, also when Valid counts them as being within half a step
+	var quarter struct {
+		X float64 `range:"0..1 step 0.25"`
+	}
+	quarter.X = 0.3
+	assert.Equal(t, New("0..1 step 0.25").Valid(quarter.X), true)
+	assert.NotEqual(t, ValidateStruct(quarter), nil)
+	quarter.X = 0.75
+	assert.Equal(t, ValidateStruct(quarter), nil)
+
+	// Bash brace expansions have a step size, also for float fields
+	var third struct {
+		X float64 `range:"{1..10..3}"`
+	}
+	third.X = 7
+	assert.Equal(t, ValidateStruct(third), nil)
+	third.X = 2
+	assert.NotEqual(t, ValidateStruct(third), nil)
+	third.X = 5.5
+	assert.NotEqual(t, ValidateStruct(third), nil)
+}
+
+type testNode struct {
+	Weight float32 `range:"0..1 step 0.1"`
+	Next   *testNode
+}
+
+func TestValidateFloat32AndCycles(t *testing.T) {
+	// float32 values are compared with the precision of a float32
+	for _, weight := range []float32{0, 0.1, 0.3, 0.7, 1} {
+		assert.Equal(t, ValidateStruct(testNode{Weight: weight}), nil)
+	}
+	assert.NotEqual(t, ValidateStruct(testNode{Weight: 0.35}), nil)
+	assert.NotEqual(t, ValidateStruct(testNode{Weight: 1.1}), nil)
+
+	// Structs that refer to themselves are only checked once
+	a := &testNode{Weight: 0.2}
+	b := &testNode{Weight: 0.25, Next: a}
+	a.Next = b
+	err := ValidateStruct(a)
+	errs, ok := err.(ValidationErrors)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Path, "Next.Weight")
+	assert.Equal(t, errs[0].Value, 0.25)
+}
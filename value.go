@@ -0,0 +1,166 @@
+package rangetype
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// OverflowPolicy is what happens when the result of an arithmetic operation on a Value is not in the range
+type OverflowPolicy int
+
+const (
+	OverflowError    OverflowPolicy = iota // return an error
+	OverflowPanic                          // panic
+	OverflowSaturate                       // use the first or last number in the range, whichever is closest
+	OverflowWrap                           // wrap around, like for modular types and unsigned integers
+)
+
+var (
+	ErrOverflow       = errors.New("VALUE OUT OF RANGE")
+	ErrDivisionByZero = errors.New("DIVISION BY ZERO")
+)
+
+// Value is a number that is constrained by a range, like a variable of a number type.
+// The result of an arithmetic operation is snapped to the step grid of the range, so that for a range
+// with the step size 0.125, the result is always a multiple of 0.125 from the start value.
+// If the result is not in the range, the overflow policy decides what happens.
+type Value struct {
+	r      *Range
+	x      float64
+	policy OverflowPolicy
+}
+
+// NewValue returns a value in the given range, with the given overflow policy.
+// The number is snapped to the step grid, and the overflow policy is applied if it is not in the range.
+func NewValue(r *Range, x float64, policy OverflowPolicy) (Value, error) {
+	return Value{r: r, policy: policy}.with(x)
+}
+
+// with returns a value in the same range and with the same policy, but with x snapped to the
+// step grid and checked against the range, according to the overflow policy
+func (v Value) with(x float64) (Value, error) {
+	r := v.r
+	if math.IsNaN(x) {
+		return v.overflow(fmt.Errorf("%w: %v is not a number", ErrOverflow, x))
+	}
+	if r.step != 0 && !math.IsInf(x, 0) {
+		x = r.snap(x, math.Round)
+	}
+	if r.precision > 0 {
+		x, _ = strconv.ParseFloat(strconv.FormatFloat(x, 'g', r.precision, 64), 64)
+	}
+	if r.validStep(x) {
+		v.x = x
+		return v, nil
+	}
+	err := fmt.Errorf("%w: %v %v", ErrOverflow, x, mustBeIn(r))
+	first, last := min(r.First(), r.Last()), max(r.First(), r.Last())
+	switch {
+	case r.IsEmpty():
+		return v.overflow(err)
+	case v.policy == OverflowSaturate && x <= first:
+		x = first
+	case v.policy == OverflowSaturate:
+		x = last
+	case v.policy == OverflowWrap && (math.IsInf(first, 0) || math.IsInf(last, 0) || math.IsInf(x, 0)):
+		return v.overflow(err)
+	case v.policy == OverflowWrap && r.step == 0:
+		// A continuous range wraps around at the width of the range
+		x = first + mod(x-first, last-first)
+	case v.policy == OverflowWrap:
+		step := abs(r.step)
+		count := math.Round((last-first)/step) + 1
+		x = first + mod(math.Round((x-first)/step), count)*step
+	}
+	if !r.validStep(x) {
+		return v.overflow(err)
+	}
+	v.x = x
+	return v, nil
+}
+
+// overflow returns or panics with the given error, according to the overflow policy
+func (v Value) overflow(err error) (Value, error) {
+	if v.policy == OverflowPanic {
+		panic(err)
+	}
+	return v, err
+}
+
+// snap returns the number on the step grid of the range that is closest to x,
+// where round decides which whole number of steps to use
+func (r *Range) snap(x float64, round func(float64) float64) float64 {
+	if r.step == 0 {
+		return x
+	}
+	anchor := r.anchor()
+	steps := (x - anchor) / r.step
+	if nearest := math.Round(steps); abs(steps-nearest) < stepTolerance {
+		// Avoid rounding errors, like for 0.3 / 0.1
+		return anchor + nearest*r.step
+	}
+	return anchor + round(steps)*r.step
+}
+
+// mod returns the remainder of x / y, which is never negative for positive y
+func mod(x, y float64) float64 {
+	m := math.Mod(x, y)
+	if m < 0 {
+		m += y
+	}
+	return m
+}
+
+// Range returns the range of the value
+func (v Value) Range() *Range {
+	return v.r
+}
+
+// Float returns the value as a float64
+func (v Value) Float() float64 {
+	return v.x
+}
+
+// Int returns the value as an int, truncated towards zero
+func (v Value) Int() int {
+	return int(v.x)
+}
+
+// String returns the value as a string
+func (v Value) String() string {
+	return strconv.FormatFloat(v.x, 'g', -1, 64)
+}
+
+// Add returns v + w, in the range of v
+func (v Value) Add(w Value) (Value, error) {
+	return v.with(v.x + w.x)
+}
+
+// Sub returns v - w, in the range of v
+func (v Value) Sub(w Value) (Value, error) {
+	return v.with(v.x - w.x)
+}
+
+// Mul returns v * w, in the range of v
+func (v Value) Mul(w Value) (Value, error) {
+	return v.with(v.x * w.x)
+}
+
+// Div returns v / w, in the range of v. For integer ranges, the result is truncated towards zero, like in Go.
+func (v Value) Div(w Value) (Value, error) {
+	if w.x == 0 {
+		return v.overflow(ErrDivisionByZero)
+	}
+	x := v.x / w.x
+	if v.r.Integer() {
+		x = math.Trunc(x)
+	}
+	return v.with(x)
+}
+
+// Neg returns -v, in the range of v
+func (v Value) Neg() (Value, error) {
+	return v.with(-v.x)
+}
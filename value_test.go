@@ -0,0 +1,134 @@
+package rangetype
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bmizerany/assert"
+)
+
+// value returns a value in the given range, or panics
+func value(r *Range, x float64, policy OverflowPolicy) Value {
+	v, err := NewValue(r, x, policy)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestValueArithmetic(t *testing.T) {
+	a, b := value(I8, 100, OverflowError), value(I8, 7, OverflowError)
+	for _, test := range []struct {
+		f        func(Value) (Value, error)
+		expected float64
+	}{
+		{a.Add, 107},
+		{a.Sub, 93},
+		{a.Div, 14},
+		{value(I8, -100, OverflowError).Div, -14},
+	} {
+		result, err := test.f(b)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, result.Float(), test.expected)
+		assert.Equal(t, result.Range(), I8)
+	}
+	neg, err := a.Neg()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, neg.Int(), -100)
+
+	_, err = a.Mul(b)
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	assert.Equal(t, err.Error(), "VALUE OUT OF RANGE: 700 must be in [-128, 127]")
+	_, err = a.Add(value(I8, 28, OverflowError))
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	_, err = value(I8, -128, OverflowError).Neg()
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+	_, err = a.Div(value(I8, 0, OverflowError))
+	assert.Equal(t, err, ErrDivisionByZero)
+
+	_, err = NewValue(U8, 256, OverflowError)
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+}
+
+func TestOverflowPolicies(t *testing.T) {
+	a, b := value(U8, 250, OverflowSaturate), value(U8, 10, OverflowSaturate)
+	sum, err := a.Add(b)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, sum.Float(), 255.0)
+	difference, err := b.Sub(a)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, difference.Float(), 0.0)
+
+	a, b = value(U8, 250, OverflowWrap), value(U8, 10, OverflowWrap)
+	sum, err = a.Add(b)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, sum.Float(), 4.0)
+	difference, err = b.Sub(a)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, difference.Float(), 16.0)
+	product, err := a.Mul(b)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, product.Float(), 2500.0-9*256)
+
+	i, err := value(I8, 127, OverflowWrap).Add(value(I8, 1, OverflowWrap))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, i.Float(), -128.0)
+
+	// Exclusive bounds
+	r := New("(0,10)")
+	s, err := value(r, 5, OverflowSaturate).Mul(value(r, 3, OverflowSaturate))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s.Float(), 9.0)
+	s, err = value(r, 5, OverflowSaturate).Neg()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, s.Float(), 1.0)
+	w, err := value(r, 5, OverflowWrap).Add(value(r, 5, OverflowWrap))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, w.Float(), 1.0)
+
+	// Continuous ranges wrap around at the width of the range
+	unit := New("[0,1) step 0")
+	w, err = value(unit, 0.5, OverflowWrap).Add(value(unit, 0.75, OverflowWrap))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, w.Float(), 0.25)
+
+	// Unbounded ranges can not wrap
+	positive := NewDialect("0..", DialectRust)
+	_, err = value(positive, 1, OverflowWrap).Neg()
+	assert.Equal(t, errors.Is(err, ErrOverflow), true)
+
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		value(U8, 255, OverflowPanic).Add(value(U8, 1, OverflowPanic))
+		return false
+	}()
+	assert.Equal(t, panicked, true)
+}
+
+func TestValueSnapping(t *testing.T) {
+	Volt := NewAda("type Volt is delta 0.125 range 0.0 .. 255.0;")
+	v := value(Volt, 0.3, OverflowError)
+	assert.Equal(t, v.Float(), 0.25)
+	third, err := value(Volt, 1, OverflowError).Div(value(Volt, 3, OverflowError))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, third.Float(), 0.375)
+	product, err := value(Volt, 0.375, OverflowError).Mul(value(Volt, 0.375, OverflowError))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, product.Float(), 0.125)
+	assert.Equal(t, Volt.Valid(product.Float()), true)
+
+	r := New("0..1 step 0.1")
+	sum, err := value(r, 0.1, OverflowError).Add(value(r, 0.2, OverflowError))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, r.Valid(sum.Float()), true)
+
+	w, err := value(r, 0.9, OverflowWrap).Add(value(r, 0.3, OverflowWrap))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, r.Valid(w.Float()), true)
+	assert.Equal(t, w.String(), "0.1")
+
+	Real := NewAda("type Short_Real is digits 3 range -1.0E6 .. 1.0E6;")
+	q, err := value(Real, 1, OverflowError).Div(value(Real, 3, OverflowError))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, q.Float(), 0.333)
+}